@@ -0,0 +1,196 @@
+package approval
+
+import (
+	"sync"
+	"time"
+
+	"github.com/boasihq/interactive-inputs/internal/errors"
+	"github.com/boasihq/interactive-inputs/internal/toolbox"
+)
+
+// Approval is an approval given towards the quorum
+type Approval struct {
+
+	// Identity is who gave the approval
+	Identity string
+
+	// Teams are the approver teams the identity is a member of
+	Teams []string
+
+	// Reason is the reason given for the approval
+	Reason string
+
+	// ApprovedAt is when the approval was given
+	ApprovedAt time.Time
+}
+
+// NewQuorumRequest holds everything needed to create a quorum
+type NewQuorumRequest struct {
+
+	// RequiredApprovals is the number of approvals needed to reach the quorum
+	RequiredApprovals int
+
+	// ApproverTeams are the teams, as org/team-slug, whose members can approve. Anyone can
+	// approve when empty
+	ApproverTeams []string
+
+	// RequireEachTeam is true when the quorum also needs an approval from a member of each
+	// of the approver teams
+	RequireEachTeam bool
+}
+
+// Quorum collects the approvals given by distinct identities until enough have been given
+// for the request to be approved, or the request is vetoed. It is safe for concurrent use.
+type Quorum struct {
+	mu sync.Mutex
+
+	// requiredApprovals is the number of approvals needed to reach the quorum
+	requiredApprovals int
+
+	// approverTeams are the teams whose members can approve
+	approverTeams []string
+
+	// requireEachTeam is true when each approver team must have approved
+	requireEachTeam bool
+
+	// approvals are the approvals given so far, in the order they were given
+	approvals []Approval
+
+	// decided is true once the quorum has been reached or the request vetoed
+	decided bool
+}
+
+// NewQuorum returns a quorum with no approvals. At least one approval is always required.
+func NewQuorum(r *NewQuorumRequest) *Quorum {
+	requiredApprovals := r.RequiredApprovals
+	if requiredApprovals < 1 {
+		requiredApprovals = 1
+	}
+
+	return &Quorum{
+		requiredApprovals: requiredApprovals,
+		approverTeams:     r.ApproverTeams,
+		requireEachTeam:   r.RequireEachTeam && len(r.ApproverTeams) > 0,
+	}
+}
+
+// Approve records the approval, returning whether it completes the quorum. An identity can
+// only approve once, and nothing more is recorded once the request has been decided.
+func (q *Quorum) Approve(approval Approval) (bool, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.decided {
+		return false, errors.ErrAlreadyDecided
+	}
+
+	for _, existing := range q.approvals {
+		if approval.Identity != "" && existing.Identity == approval.Identity {
+			return false, errors.ErrAlreadyApproved
+		}
+	}
+
+	if approval.ApprovedAt.IsZero() {
+		approval.ApprovedAt = time.Now().UTC()
+	}
+
+	q.approvals = append(q.approvals, approval)
+	q.decided = q.reached()
+
+	return q.decided, nil
+}
+
+// Veto rejects the request, so no more decisions can be made
+func (q *Quorum) Veto() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.decided {
+		return errors.ErrAlreadyDecided
+	}
+
+	q.decided = true
+
+	return nil
+}
+
+// reached returns whether enough approvals have been given, including one from each
+// approver team when required
+func (q *Quorum) reached() bool {
+	if len(q.approvals) < q.requiredApprovals {
+		return false
+	}
+
+	if !q.requireEachTeam {
+		return true
+	}
+
+	for _, team := range q.approverTeams {
+		var approved bool
+		for _, approval := range q.approvals {
+			if toolbox.StringInSlice(team, approval.Teams) {
+				approved = true
+				break
+			}
+		}
+
+		if !approved {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Status returns the approvals given so far and the number required
+func (q *Quorum) Status() Status {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return Status{
+		RequiredApprovals: q.requiredApprovals,
+		ApproverTeams:     q.approverTeams,
+		RequireEachTeam:   q.requireEachTeam,
+		Approvals:         append([]Approval(nil), q.approvals...),
+	}
+}
+
+// ApproverTeams returns the teams whose members can approve
+func (q *Quorum) ApproverTeams() []string {
+	return q.approverTeams
+}
+
+// Status is a snapshot of the approvals given towards the quorum
+type Status struct {
+
+	// RequiredApprovals is the number of approvals needed to reach the quorum
+	RequiredApprovals int
+
+	// ApproverTeams are the teams whose members can approve
+	ApproverTeams []string
+
+	// RequireEachTeam is true when each approver team must have approved
+	RequireEachTeam bool
+
+	// Approvals are the approvals given so far, in the order they were given
+	Approvals []Approval
+}
+
+// Approvers returns the identities that have approved so far, in the order they approved
+func (s Status) Approvers() []string {
+	var approvers []string = make([]string, 0, len(s.Approvals))
+	for _, approval := range s.Approvals {
+		approvers = append(approvers, approval.Identity)
+	}
+
+	return approvers
+}
+
+// Remaining returns the number of approvals still needed to reach the quorum
+func (s Status) Remaining() int {
+	if remaining := s.RequiredApprovals - len(s.Approvals); remaining > 0 {
+		return remaining
+	}
+
+	return 0
+}
@@ -0,0 +1,101 @@
+package approval_test
+
+import (
+	"testing"
+
+	"github.com/boasihq/interactive-inputs/internal/approval"
+	"github.com/boasihq/interactive-inputs/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuorum_Approve(t *testing.T) {
+	type decision struct {
+		approval        approval.Approval
+		veto            bool
+		expectedDecided bool
+		expectedError   error
+	}
+
+	tests := []struct {
+		name              string
+		request           approval.NewQuorumRequest
+		decisions         []decision
+		expectedApprovers []string
+	}{
+		{
+			name:    "a single approval completes the quorum by default",
+			request: approval.NewQuorumRequest{},
+			decisions: []decision{
+				{approval: approval.Approval{Identity: "alice"}, expectedDecided: true},
+			},
+			expectedApprovers: []string{"alice"},
+		},
+		{
+			name:    "the quorum waits for the required approvals",
+			request: approval.NewQuorumRequest{RequiredApprovals: 2},
+			decisions: []decision{
+				{approval: approval.Approval{Identity: "alice"}},
+				{approval: approval.Approval{Identity: "bob"}, expectedDecided: true},
+			},
+			expectedApprovers: []string{"alice", "bob"},
+		},
+		{
+			name:    "the same identity can't approve twice",
+			request: approval.NewQuorumRequest{RequiredApprovals: 2},
+			decisions: []decision{
+				{approval: approval.Approval{Identity: "alice"}},
+				{approval: approval.Approval{Identity: "alice"}, expectedError: errors.ErrAlreadyApproved},
+			},
+			expectedApprovers: []string{"alice"},
+		},
+		{
+			name:    "nothing is recorded once the quorum is reached",
+			request: approval.NewQuorumRequest{},
+			decisions: []decision{
+				{approval: approval.Approval{Identity: "alice"}, expectedDecided: true},
+				{approval: approval.Approval{Identity: "bob"}, expectedError: errors.ErrAlreadyDecided},
+				{veto: true, expectedError: errors.ErrAlreadyDecided},
+			},
+			expectedApprovers: []string{"alice"},
+		},
+		{
+			name:    "a veto decides the request",
+			request: approval.NewQuorumRequest{RequiredApprovals: 3},
+			decisions: []decision{
+				{approval: approval.Approval{Identity: "alice"}},
+				{veto: true},
+				{approval: approval.Approval{Identity: "bob"}, expectedError: errors.ErrAlreadyDecided},
+			},
+			expectedApprovers: []string{"alice"},
+		},
+		{
+			name:    "each approver team must approve when required",
+			request: approval.NewQuorumRequest{RequiredApprovals: 2, ApproverTeams: []string{"acme/sre", "acme/security"}, RequireEachTeam: true},
+			decisions: []decision{
+				{approval: approval.Approval{Identity: "alice", Teams: []string{"acme/sre"}}},
+				{approval: approval.Approval{Identity: "bob", Teams: []string{"acme/sre"}}},
+				{approval: approval.Approval{Identity: "carol", Teams: []string{"acme/security"}}, expectedDecided: true},
+			},
+			expectedApprovers: []string{"alice", "bob", "carol"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quorum := approval.NewQuorum(&tt.request)
+
+			for _, d := range tt.decisions {
+				if d.veto {
+					assert.Equal(t, d.expectedError, quorum.Veto())
+					continue
+				}
+
+				decided, err := quorum.Approve(d.approval)
+				assert.Equal(t, d.expectedError, err)
+				assert.Equal(t, d.expectedDecided, decided)
+			}
+
+			assert.Equal(t, tt.expectedApprovers, quorum.Status().Approvers())
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/boasihq/interactive-inputs/internal/errors"
+	"github.com/boasihq/interactive-inputs/internal/membership"
+	githubactions "github.com/sethvargo/go-githubactions"
+)
+
+// DefaultRequiredApprovals is the default number of approvals needed before the portal completes
+const DefaultRequiredApprovals int = 1
+
+// approvalsFromInputs returns the number of approvals needed before the portal completes
+// in decision mode, the teams whose members can approve, and whether each team must have
+// approved. Approvers are told apart by their identity, so the identity header must be
+// provided to collect more than one approval or restrict approvals to teams.
+func approvalsFromInputs(action *githubactions.Action, mode, identityHeader string) (int, []string, bool, error) {
+	var requiredApprovals int = DefaultRequiredApprovals
+	var approverTeams []string
+
+	if requiredApprovalsInput := strings.TrimSpace(getInput(action, "required-approvals")); requiredApprovalsInput != "" {
+		var err error
+		requiredApprovals, err = strconv.Atoi(requiredApprovalsInput)
+		if err != nil || requiredApprovals < 1 {
+			action.Errorf("Cannot convert the 'required-approvals' input (%s) to a number of at least 1", requiredApprovalsInput)
+			return 0, nil, false, errors.ErrInvalidApprovalSettingsProvided
+		}
+	}
+
+	for _, team := range splitList(getInput(action, "approver-teams")) {
+		normalisedTeam, ok := membership.NormaliseTeam(team)
+		if !ok {
+			action.Errorf("Invalid team '%s' provided in the 'approver-teams' input - teams must be given as org/team-slug", team)
+			return 0, nil, false, errors.ErrInvalidApprovalSettingsProvided
+		}
+
+		approverTeams = append(approverTeams, normalisedTeam)
+	}
+
+	requireEachTeam := getInput(action, "require-approval-from-each-team") == "true"
+
+	if requireEachTeam && len(approverTeams) == 0 {
+		action.Errorf("The 'require-approval-from-each-team' input can only be used when 'approver-teams' are provided")
+		return 0, nil, false, errors.ErrInvalidApprovalSettingsProvided
+	}
+
+	if requiredApprovals == DefaultRequiredApprovals && len(approverTeams) == 0 {
+		return requiredApprovals, nil, false, nil
+	}
+
+	if mode != ModeDecision {
+		action.Errorf("The 'required-approvals' and 'approver-teams' inputs can only be used in %s mode", ModeDecision)
+		return 0, nil, false, errors.ErrInvalidApprovalSettingsProvided
+	}
+
+	if identityHeader == "" {
+		action.Errorf("The 'identity-header' input must be provided to identify approvers when 'required-approvals' or 'approver-teams' are used")
+		return 0, nil, false, errors.ErrInvalidApprovalSettingsProvided
+	}
+
+	return requiredApprovals, approverTeams, requireEachTeam, nil
+}
+
+// splitList returns the non-empty items of a comma or newline separated list
+func splitList(list string) []string {
+	var items []string
+	for _, item := range strings.FieldsFunc(list, func(r rune) bool { return r == ',' || r == '\n' }) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
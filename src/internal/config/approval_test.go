@@ -0,0 +1,122 @@
+package config_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/boasihq/interactive-inputs/internal/config"
+	"github.com/boasihq/interactive-inputs/internal/errors"
+	githubactions "github.com/sethvargo/go-githubactions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_NewFromInputsApprovals(t *testing.T) {
+	tests := []struct {
+		name                      string
+		envMap                    map[string]string
+		expectedIdentityHeader    string
+		expectedRequiredApprovals int
+		expectedApproverTeams     []string
+		expectedRequireEachTeam   bool
+		expectedOutput            string
+		expectedError             error
+	}{
+		{
+			name:                      "successful - a single approval by default",
+			envMap:                    map[string]string{},
+			expectedRequiredApprovals: 1,
+		},
+		{
+			name: "successful - quorum of approver teams identified by a proxy header",
+			envMap: map[string]string{
+				"INPUT_MODE":                            "decision",
+				"INPUT_IDENTITY-HEADER":                 "x-forwarded-user",
+				"INPUT_REQUIRED-APPROVALS":              "2",
+				"INPUT_APPROVER-TEAMS":                  "@acme/sre, acme/security\n",
+				"INPUT_REQUIRE-APPROVAL-FROM-EACH-TEAM": "true",
+			},
+			expectedIdentityHeader:    "X-Forwarded-User",
+			expectedRequiredApprovals: 2,
+			expectedApproverTeams:     []string{"acme/sre", "acme/security"},
+			expectedRequireEachTeam:   true,
+		},
+		{
+			name:           "failed - required approvals isn't a number",
+			envMap:         map[string]string{"INPUT_MODE": "decision", "INPUT_REQUIRED-APPROVALS": "two"},
+			expectedOutput: "::error::Cannot convert the 'required-approvals' input (two) to a number of at least 1\n",
+			expectedError:  errors.ErrInvalidApprovalSettingsProvided,
+		},
+		{
+			name:           "failed - required approvals is less than one",
+			envMap:         map[string]string{"INPUT_MODE": "decision", "INPUT_REQUIRED-APPROVALS": "0"},
+			expectedOutput: "::error::Cannot convert the 'required-approvals' input (0) to a number of at least 1\n",
+			expectedError:  errors.ErrInvalidApprovalSettingsProvided,
+		},
+		{
+			name:           "failed - team without an organisation",
+			envMap:         map[string]string{"INPUT_MODE": "decision", "INPUT_APPROVER-TEAMS": "sre"},
+			expectedOutput: "::error::Invalid team 'sre' provided in the 'approver-teams' input - teams must be given as org/team-slug\n",
+			expectedError:  errors.ErrInvalidApprovalSettingsProvided,
+		},
+		{
+			name:           "failed - each team required without approver teams",
+			envMap:         map[string]string{"INPUT_MODE": "decision", "INPUT_REQUIRE-APPROVAL-FROM-EACH-TEAM": "true"},
+			expectedOutput: "::error::The 'require-approval-from-each-team' input can only be used when 'approver-teams' are provided\n",
+			expectedError:  errors.ErrInvalidApprovalSettingsProvided,
+		},
+		{
+			name:           "failed - quorum outside of decision mode",
+			envMap:         map[string]string{"INPUT_IDENTITY-HEADER": "X-Forwarded-User", "INPUT_REQUIRED-APPROVALS": "2"},
+			expectedOutput: "::error::The 'required-approvals' and 'approver-teams' inputs can only be used in decision mode\n",
+			expectedError:  errors.ErrInvalidApprovalSettingsProvided,
+		},
+		{
+			name:           "failed - quorum without an identity header",
+			envMap:         map[string]string{"INPUT_MODE": "decision", "INPUT_REQUIRED-APPROVALS": "2"},
+			expectedOutput: "::error::The 'identity-header' input must be provided to identify approvers when 'required-approvals' or 'approver-teams' are used\n",
+			expectedError:  errors.ErrInvalidApprovalSettingsProvided,
+		},
+		{
+			name: "failed - fields limited to teams without an identity header",
+			envMap: map[string]string{
+				"INPUT_INTERACTIVE": "fields:\n  - label: skip-canary\n    properties:\n      type: boolean\n      editableBy: [acme/sre]\n",
+			},
+			expectedOutput: "::error::The 'identity-header' input must be provided to identify who is responding when fields use editableBy or selectableBy\n",
+			expectedError:  errors.ErrInvalidPermissionProvided,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actionLog := bytes.NewBuffer(nil)
+
+			envMap := map[string]string{
+				"INPUT_INTERACTIVE":           "fields:\n  - label: name\n    properties:\n      type: text\n",
+				"INPUT_GITHUB-TOKEN":          "github-secret-token",
+				"INPUT_SELFHOSTED-PUBLIC-URL": "https://example.com/inputs",
+				"INPUT_TIMEOUT":               "300",
+			}
+			for key, value := range test.envMap {
+				envMap[key] = value
+			}
+
+			action := githubactions.New(
+				githubactions.WithWriter(actionLog),
+				githubactions.WithGetenv(func(key string) string { return envMap[key] }),
+			)
+
+			cfg, err := config.NewFromInputs(action)
+			if test.expectedError != nil {
+				assert.Equal(t, test.expectedError, err)
+				assert.Equal(t, test.expectedOutput, actionLog.String())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedIdentityHeader, cfg.IdentityHeader)
+			assert.Equal(t, test.expectedRequiredApprovals, cfg.RequiredApprovals)
+			assert.Equal(t, test.expectedApproverTeams, cfg.ApproverTeams)
+			assert.Equal(t, test.expectedRequireEachTeam, cfg.RequireApprovalFromEachTeam)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package config
 
 import (
     "fmt"
+    "net/http"
     "strconv"
     "strings"
 
@@ -15,9 +16,60 @@ type Config struct {
 	// Title is the header that will be displayed at the top of the generated form
 	Title string
 
+	// Intro is the markdown that will be displayed at the top of the generated form,
+	// below the title
+	Intro string
+
 	// Fields is the slice of fields that will be displayed in the generated form
 	Fields *fields.Fields
 
+	// Mode determines how the portal is completed, either submitted as a form (default)
+	// or approved/rejected as a decision
+	Mode string
+
+	// DecisionReasonRequired determines when a reason must be given for the decision,
+	// never (false), always (true) or only when rejecting (on-reject). Only used in decision mode
+	DecisionReasonRequired string
+
+	// DecisionOnReject determines whether the step fails (fail) or succeeds (continue) when
+	// the portal is rejected. Only used in decision mode
+	DecisionOnReject string
+
+	// GateChecklist are the items that must all be ticked before the portal is acknowledged.
+	// Only used in gate mode
+	GateChecklist []string
+
+	// IdentityHeader is the request header, set by a trusted proxy in front of the portal,
+	// that identifies who is using the portal, i.e. X-Forwarded-User
+	IdentityHeader string
+
+	// RequiredApprovals is the number of approvals, from distinct identities, needed before
+	// the portal completes in decision mode
+	RequiredApprovals int
+
+	// ApproverTeams are the GitHub teams, as org/team-slug, whose members can make the
+	// decision in decision mode. Anyone can when empty
+	ApproverTeams []string
+
+	// RequireApprovalFromEachTeam is true when the approvals must include one from a member
+	// of each of the approver teams
+	RequireApprovalFromEachTeam bool
+
+	// SharedDraft is true when everyone filling in the portal shares the same draft, rather
+	// than each session having its own
+	SharedDraft bool
+
+	// ReviewBeforeSubmit is true when the values entered into the portal are reviewed, as
+	// they will be set as outputs, before they are submitted
+	ReviewBeforeSubmit bool
+
+	// ResultGracePeriod is how long, in seconds, the read-only result page is served once the
+	// portal has been completed. The action finishes straight away when zero
+	ResultGracePeriod int
+
+	// JobSummary is true when the result of the portal is written to the job summary
+	JobSummary bool
+
 	// Timeout is the timeout that will be used to manage how long the portal
 	// will be available for users to use before it is automatically deactivated
 	Timeout int
@@ -90,6 +142,31 @@ const (
 	DefaultSelfHostedListenAddress string = ":8080"
 )
 
+// defaultInteractiveInput is the example fields config shown when neither the 'interactive'
+// nor the 'interactive-file' input is provided in form mode
+const defaultInteractiveInput string = `fields:
+  - label: requested-files
+    properties:
+      display: Upload desired files
+      type: multifile
+      required: true
+      description: Upload desired files that are to be uploaded to the runner for processing
+  - label: random-string
+    properties:
+      display: Enter a random string
+      type: text
+      description: A random string up to 20 characters long
+      maxLength: 20
+      required: false
+  - label: choice
+    properties:
+      display: Select a monitoring tool
+      type: select
+      description: Available options to chose from
+      choices: ["datadog", "sentry", "grafana"]
+      required: true
+`
+
 const (
     // PortalHostModeSelfHosted exposes the portal via a self-hosted HTTP listener
     PortalHostModeSelfHosted string = "self-hosted"
@@ -150,12 +227,98 @@ func NewFromInputs(action *githubactions.Action) (*Config, error) {
 		action.Debugf("Title input provided: %s", titleInput)
 	}
 
-	// handle input for fetching interactive inputs portal fields if provided
+	// handle input for fetching form intro if provided
+	introInput := getInput(action, "intro")
+
+	// make sure templated title and intro can be expanded when the portal is displayed
+	if err := fields.ValidateTemplate(titleInput); err != nil {
+		action.Errorf("Invalid template provided for the 'title' input: %s", err)
+		return nil, errors.ErrInvalidTemplateProvided
+	}
+
+	if err := fields.ValidateTemplate(introInput); err != nil {
+		action.Errorf("Invalid template provided for the 'intro' input: %s", err)
+		return nil, errors.ErrInvalidTemplateProvided
+	}
+
+	// handle input for fetching the mode the portal is operated in
+	mode, err := modeFromInputs(action)
+	if err != nil {
+		return nil, err
+	}
+
+	// handle input for fetching interactive inputs portal fields if provided, the fields
+	// are given inline or in a fields file, but not both
 	interactiveInput := getInput(action, "interactive")
-	fields, err := fields.MarshalStringIntoValidFieldsStruct(interactiveInput, action)
+	interactiveFileInput := strings.TrimSpace(getInput(action, "interactive-file"))
+
+	if strings.TrimSpace(interactiveInput) != "" && interactiveFileInput != "" {
+		action.Errorf("The 'interactive' and 'interactive-file' inputs can't both be provided - give the fields inline or in a fields file")
+		return nil, errors.ErrInvalidFieldsFileProvided
+	}
+
+	// a gate has no fields, only the checklist ticked before it is acknowledged
+	gateChecklist, err := gateFromInputs(action, mode, interactiveInput, interactiveFileInput)
+	if err != nil {
+		return nil, err
+	}
+
+	// the example fields are shown when a form is used without any fields being provided,
+	// whereas a decision can be made without fields
+	fieldsProvided := strings.TrimSpace(interactiveInput) != "" || interactiveFileInput != ""
+	if mode == ModeForm && !fieldsProvided {
+		interactiveInput = defaultInteractiveInput
+		fieldsProvided = true
+	}
+
+	var interactiveFields *fields.Fields
+	if mode != ModeGate && fieldsProvided {
+		fieldsConfig, err := resolveFieldsConfig(action, interactiveInput, interactiveFileInput)
+		if err != nil {
+			return nil, err
+		}
+
+		interactiveFields, err = fields.MarshalStringIntoValidFieldsStruct(fieldsConfig, action)
+		if err != nil {
+			if interactiveFileInput != "" {
+				action.Errorf("Can't convert the fields file '%s' to a valid fields config", interactiveFileInput)
+				return nil, errors.ErrMalformedFieldsInputDataProvided
+			}
+
+			action.Errorf("Can't convert the 'fields' input to a valid fields config: %s", fieldsConfig)
+			return nil, errors.ErrMalformedFieldsInputDataProvided
+		}
+	}
+
+	// handle input for fetching how the decision is made in decision mode
+	decisionReasonRequired, decisionOnReject, err := decisionFromInputs(action, mode, interactiveFields)
+	if err != nil {
+		return nil, err
+	}
+
+	// handle input for fetching who is using the portal, and the approvals needed
+	identityHeader := http.CanonicalHeaderKey(strings.TrimSpace(getInput(action, "identity-header")))
+	requiredApprovals, approverTeams, requireApprovalFromEachTeam, err := approvalsFromInputs(action, mode, identityHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	// fields limited to teams are enforced against who is using the portal
+	if interactiveFields != nil && interactiveFields.HasPermissions() && identityHeader == "" {
+		action.Errorf("The 'identity-header' input must be provided to identify who is responding when fields use editableBy or selectableBy")
+		return nil, errors.ErrInvalidPermissionProvided
+	}
+
+	// handle input for fetching whether the draft saved as the portal is filled in is shared
+	sharedDraft := getInput(action, "shared-draft") == "true"
+
+	// handle input for fetching whether the entered values are reviewed before they are submitted
+	reviewBeforeSubmit := getInput(action, "review-before-submit") == "true"
+
+	// handle input for fetching how the result is shared once the portal has been completed
+	resultGracePeriod, jobSummary, err := resultFromInputs(action)
 	if err != nil {
-		action.Errorf("Can't convert the 'fields' input to a valid fields config: %s", interactiveInput)
-		return nil, errors.ErrMalformedFieldsInputDataProvided
+		return nil, err
 	}
 
     // handle input for fetching slack notifier
@@ -227,7 +390,21 @@ func NewFromInputs(action *githubactions.Action) (*Config, error) {
 
     c := Config{
         Title:                   titleInput,
-        Fields:                  fields,
+        Intro:                   introInput,
+        Fields:                  interactiveFields,
+        Mode:                    mode,
+        DecisionReasonRequired:  decisionReasonRequired,
+        DecisionOnReject:        decisionOnReject,
+        GateChecklist:           gateChecklist,
+
+        IdentityHeader:              identityHeader,
+        RequiredApprovals:           requiredApprovals,
+        ApproverTeams:               approverTeams,
+        RequireApprovalFromEachTeam: requireApprovalFromEachTeam,
+        SharedDraft:                 sharedDraft,
+        ReviewBeforeSubmit:          reviewBeforeSubmit,
+        ResultGracePeriod:           resultGracePeriod,
+        JobSummary:                  jobSummary,
         Timeout:                 timeout,
         PortalHostMode:          portalHostModeInput,
         SelfHostedListenAddress: selfHostedListenAddress,
@@ -40,6 +40,8 @@ func TestConfig_NewFromInputs(t *testing.T) {
             SelfHostedListenAddress: config.DefaultSelfHostedListenAddress,
             SelfHostedPublicURL:     "https://example.com/inputs",
             RunnerEndpointKey:       "runner",
+            Mode:                    config.ModeForm,
+            RequiredApprovals:       config.DefaultRequiredApprovals,
             Fields: &fields.Fields{
 					Fields: []fields.Field{
 						{
@@ -84,6 +86,8 @@ func TestConfig_NewFromInputs(t *testing.T) {
             SelfHostedListenAddress: config.DefaultSelfHostedListenAddress,
             SelfHostedPublicURL:     "https://example.com/inputs",
             RunnerEndpointKey:       "runner",
+            Mode:                    config.ModeForm,
+            RequiredApprovals:       config.DefaultRequiredApprovals,
             Fields: &fields.Fields{
 					Fields: []fields.Field{
 						{
@@ -128,6 +132,8 @@ func TestConfig_NewFromInputs(t *testing.T) {
             SelfHostedListenAddress: config.DefaultSelfHostedListenAddress,
             SelfHostedPublicURL:     "https://example.com/inputs",
             RunnerEndpointKey:       "runner",
+            Mode:                    config.ModeForm,
+            RequiredApprovals:       config.DefaultRequiredApprovals,
             Fields: &fields.Fields{
 					Fields: []fields.Field{
 						{
@@ -172,6 +178,8 @@ func TestConfig_NewFromInputs(t *testing.T) {
             SelfHostedListenAddress: config.DefaultSelfHostedListenAddress,
             SelfHostedPublicURL:     "https://example.com/inputs",
             RunnerEndpointKey:       "runner",
+            Mode:                    config.ModeForm,
+            RequiredApprovals:       config.DefaultRequiredApprovals,
             Fields: &fields.Fields{
 					Fields: []fields.Field{
 						{
@@ -179,7 +187,7 @@ func TestConfig_NewFromInputs(t *testing.T) {
 							Properties: fields.FieldProperties{
 								Display:  "Environment names",
 								Type:     "select",
-								Choices:  []string{"option", "option2", "option3"},
+								Choices:  []fields.Choice{{Label: "option", Value: "option"}, {Label: "option2", Value: "option2"}, {Label: "option3", Value: "option3"}},
 								Required: false,
 							},
 						},
@@ -236,7 +244,7 @@ func TestConfig_NewFromInputs(t *testing.T) {
 							Properties: fields.FieldProperties{
 								Display:  "Environment names",
 								Type:     "options",
-								Choices:  []string{"option", "option2", "option3"},
+								Choices:  []fields.Choice{{Label: "option", Value: "option"}, {Label: "option2", Value: "option2"}, {Label: "option3", Value: "option3"}},
 								Required: false,
 							},
 						},
@@ -252,7 +260,7 @@ func TestConfig_NewFromInputs(t *testing.T) {
             Action:                          nil,
             GithubToken:                     "github-secret-token",
         },
-			expectedOutput: "::debug::The timeout was not provided, will use the default timeout of 300 seconds\n::debug::Title input provided: Where should application be deployed?\n::error::Invalid field type 'options' provided for field 'deployment-environment'. Valid field types are: text, textarea, number, boolean, select, multiselect, file, multifile\n::error::Can't convert the 'fields' input to a valid fields config: fields:%0A  - label: deployment-environment%0A    properties:%0A      display: Environment names%0A      type: options%0A      choices: ['option', 'option2', 'option3']\n",
+			expectedOutput: "::debug::The timeout was not provided, will use the default timeout of 300 seconds\n::debug::Title input provided: Where should application be deployed?\n::error::Invalid field type 'options' provided for field 'deployment-environment'. Valid field types are: text, textarea, number, boolean, select, multiselect, file, multifile, group, json, yaml, confirm, markdown, file-preview, diff, chart, region\n::error::Can't convert the 'fields' input to a valid fields config: fields:%0A  - label: deployment-environment%0A    properties:%0A      display: Environment names%0A      type: options%0A      choices: ['option', 'option2', 'option3']\n",
 			expectedError:  errors.ErrMalformedFieldsInputDataProvided,
 		},
 		{
@@ -274,6 +282,8 @@ func TestConfig_NewFromInputs(t *testing.T) {
             SelfHostedListenAddress: "0.0.0.0:9090",
             SelfHostedPublicURL:     "https://alb.example.com/inputs",
             RunnerEndpointKey:       "runner",
+            Mode:                    config.ModeForm,
+            RequiredApprovals:       config.DefaultRequiredApprovals,
             Fields: &fields.Fields{
 					Fields: []fields.Field{
 						{
@@ -318,6 +328,8 @@ func TestConfig_NewFromInputs(t *testing.T) {
             SelfHostedListenAddress: "0.0.0.0:9090",
             SelfHostedPublicURL:     "https://alb.example.com/inputs",
             RunnerEndpointKey:       "runner",
+            Mode:                    config.ModeForm,
+            RequiredApprovals:       config.DefaultRequiredApprovals,
             Fields: &fields.Fields{
 					Fields: []fields.Field{
 						{
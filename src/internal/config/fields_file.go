@@ -0,0 +1,382 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/boasihq/interactive-inputs/internal/errors"
+	"github.com/boasihq/interactive-inputs/internal/fields"
+	"github.com/boasihq/interactive-inputs/internal/toolbox"
+	githubactions "github.com/sethvargo/go-githubactions"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// includeKey is the key of an item in a list of fields that is replaced by the fields
+	// of another file
+	includeKey string = "$include"
+
+	// extendsKey is the key of a fields config that inherits the fields, steps and rules
+	// of one or more other files
+	extendsKey string = "extends"
+)
+
+// fieldsFileResolver resolves the files included or extended by a fields config
+type fieldsFileResolver struct {
+	action *githubactions.Action
+
+	// chain is the paths of the files currently being resolved, from the outermost file
+	// inwards, so an include cycle can be detected
+	chain []string
+}
+
+// resolveFieldsConfig returns the fields config read from the fields file if one is provided,
+// otherwise the inline config, with any includes and extends resolved. The config is
+// returned as it was provided when it doesn't include or extend any files, so problems
+// reported against it refer to the lines that were written.
+func resolveFieldsConfig(action *githubactions.Action, inlineConfig string, fieldsFile string) (string, error) {
+	resolver := &fieldsFileResolver{action: action}
+
+	source := inlineConfig
+	sourceName := "the 'interactive' input"
+	dir := action.Getenv("GITHUB_WORKSPACE")
+
+	if fieldsFile != "" {
+		path := fields.WorkspacePath(action, fieldsFile)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			action.Errorf("Unable to read the fields file '%s': %s", fieldsFile, err)
+			return "", errors.ErrInvalidFieldsFileProvided
+		}
+
+		source = string(content)
+		sourceName = fmt.Sprintf("'%s'", resolver.displayPath(path))
+		dir = filepath.Dir(path)
+		resolver.chain = []string{path}
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(source), &document); err != nil || len(document.Content) == 0 || !usesIncludes(document.Content[0]) {
+		// syntax errors are reported when the fields are unmarshalled
+		return source, nil
+	}
+
+	resolved, err := resolver.resolveDocument(document.Content[0], dir, sourceName)
+	if err != nil {
+		return "", err
+	}
+
+	encoded, err := yaml.Marshal(resolved)
+	if err != nil {
+		action.Errorf("Unable to encode the resolved fields config: %s", err)
+		return "", errors.ErrInvalidFieldsFileProvided
+	}
+
+	action.Debugf("Resolved fields config: %s", encoded)
+	return string(encoded), nil
+}
+
+// loadFile reads and resolves the fields file at the path. A file containing a list is
+// treated as a list of fields.
+func (r *fieldsFileResolver) loadFile(path string, includedBy string) (*yaml.Node, error) {
+	for i, chainPath := range r.chain {
+		if chainPath != path {
+			continue
+		}
+
+		var cycle []string
+		for _, cyclePath := range append(r.chain[i:], path) {
+			cycle = append(cycle, r.displayPath(cyclePath))
+		}
+		r.action.Errorf("Circular include detected: %s", strings.Join(cycle, " -> "))
+		return nil, errors.ErrCircularFieldsIncludeDetected
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		r.action.Errorf("Unable to read the fields file '%s' included by %s: %s", r.displayPath(path), includedBy, err)
+		return nil, errors.ErrInvalidFieldsFileProvided
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		r.action.Errorf("Unable to parse the fields file '%s': %s", r.displayPath(path), err)
+		return nil, errors.ErrInvalidFieldsFileProvided
+	}
+
+	root := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	if len(document.Content) > 0 {
+		root = document.Content[0]
+	}
+
+	if root.Kind == yaml.SequenceNode {
+		root = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{toolbox.YamlStringNode("fields"), root}}
+	}
+
+	if root.Kind != yaml.MappingNode {
+		r.action.Errorf("The fields file '%s' must contain a fields config or a list of fields", r.displayPath(path))
+		return nil, errors.ErrInvalidFieldsFileProvided
+	}
+
+	r.chain = append(r.chain, path)
+	defer func() { r.chain = r.chain[:len(r.chain)-1] }()
+
+	return r.resolveDocument(root, filepath.Dir(path), fmt.Sprintf("'%s'", r.displayPath(path)))
+}
+
+// resolveDocument returns the fields config with the files it extends merged in and the
+// files included by its lists of fields inlined. Paths are relative to the directory.
+func (r *fieldsFileResolver) resolveDocument(root *yaml.Node, dir string, name string) (*yaml.Node, error) {
+	if root.Kind != yaml.MappingNode {
+		return root, nil
+	}
+
+	extendsPaths, err := r.paths(toolbox.YamlMappingValue(root, extendsKey), extendsKey, name)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for _, extendsPath := range extendsPaths {
+		base, err := r.loadFile(resolvePath(dir, extendsPath), name)
+		if err != nil {
+			return nil, err
+		}
+
+		resolved, err = r.mergeDocuments(resolved, base, dir, name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return r.mergeDocuments(resolved, root, dir, name)
+}
+
+// mergeDocuments merges the fields config into the base config. Fields override the base
+// fields with the same label, steps replace the base steps with the same label, rules are
+// added to the base rules, and anything else replaces the base value.
+func (r *fieldsFileResolver) mergeDocuments(base *yaml.Node, document *yaml.Node, dir string, name string) (*yaml.Node, error) {
+	merged := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: append([]*yaml.Node{}, base.Content...)}
+
+	for i := 0; i+1 < len(document.Content); i += 2 {
+		key, value := document.Content[i], document.Content[i+1]
+		baseValue := toolbox.YamlMappingValue(merged, key.Value)
+
+		switch key.Value {
+		case extendsKey:
+			continue
+
+		case "fields":
+			resolvedFields, err := r.resolveFieldList(value, baseValue, dir, name)
+			if err != nil {
+				return nil, err
+			}
+			value = resolvedFields
+
+		case "steps":
+			value = mergeSequences(baseValue, value, true)
+
+		case "rules":
+			value = mergeSequences(baseValue, value, false)
+		}
+
+		toolbox.YamlSetMappingValue(merged, key, value)
+	}
+
+	return merged, nil
+}
+
+// resolveFieldList returns the list of fields with the items that include another file
+// replaced by the fields of that file, and the sub-fields of group fields resolved. A
+// field with the same label as an included or base field overrides its properties.
+func (r *fieldsFileResolver) resolveFieldList(list *yaml.Node, base *yaml.Node, dir string, name string) (*yaml.Node, error) {
+	if list.Kind != yaml.SequenceNode {
+		return list, nil
+	}
+
+	resolved := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+
+	// inherited maps the labels of the fields that can be overridden to their position
+	inherited := map[string]int{}
+	inherit := func(field *yaml.Node) {
+		if label := toolbox.YamlMappingValue(field, "label"); label != nil {
+			inherited[label.Value] = len(resolved.Content)
+		}
+		resolved.Content = append(resolved.Content, field)
+	}
+
+	if base != nil && base.Kind == yaml.SequenceNode {
+		for _, field := range base.Content {
+			inherit(field)
+		}
+	}
+
+	for _, item := range list.Content {
+		if includeValue := toolbox.YamlMappingValue(item, includeKey); includeValue != nil {
+			if len(item.Content) > 2 {
+				r.action.Errorf("The %s item of %s can't be combined with other properties (line %d) - override the included fields by label instead", includeKey, name, item.Line)
+				return nil, errors.ErrInvalidFieldsFileProvided
+			}
+
+			includePaths, err := r.paths(includeValue, includeKey, name)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, includePath := range includePaths {
+				included, err := r.loadFile(resolvePath(dir, includePath), name)
+				if err != nil {
+					return nil, err
+				}
+
+				// only the fields are included, extends should be used to inherit steps and rules
+				if includedFields := toolbox.YamlMappingValue(included, "fields"); includedFields != nil && includedFields.Kind == yaml.SequenceNode {
+					for _, field := range includedFields.Content {
+						inherit(field)
+					}
+				}
+			}
+			continue
+		}
+
+		field := item
+		if properties := toolbox.YamlMappingValue(item, "properties"); properties != nil {
+			if subFields := toolbox.YamlMappingValue(properties, "fields"); subFields != nil {
+				resolvedSubFields, err := r.resolveFieldList(subFields, nil, dir, name)
+				if err != nil {
+					return nil, err
+				}
+
+				field = mergeNodes(item, &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{
+					toolbox.YamlStringNode("properties"),
+					{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{toolbox.YamlStringNode("fields"), resolvedSubFields}},
+				}})
+			}
+		}
+
+		label := toolbox.YamlMappingValue(field, "label")
+		if label != nil {
+			if position, ok := inherited[label.Value]; ok {
+				resolved.Content[position] = mergeNodes(resolved.Content[position], field)
+
+				// a field can only be overridden once, any further field with the label is a duplicate
+				delete(inherited, label.Value)
+				continue
+			}
+		}
+
+		resolved.Content = append(resolved.Content, field)
+	}
+
+	return resolved, nil
+}
+
+// paths returns the paths provided for an include or extends, which can be a single path
+// or a list of paths
+func (r *fieldsFileResolver) paths(node *yaml.Node, key string, name string) ([]string, error) {
+	if node == nil {
+		return nil, nil
+	}
+
+	items := []*yaml.Node{node}
+	if node.Kind == yaml.SequenceNode {
+		items = node.Content
+	}
+
+	var paths []string
+	for _, item := range items {
+		if item.Kind != yaml.ScalarNode || strings.TrimSpace(item.Value) == "" {
+			r.action.Errorf("Invalid %s provided in %s (line %d) - it must be the path of a fields file, or a list of paths", key, name, item.Line)
+			return nil, errors.ErrInvalidFieldsFileProvided
+		}
+		paths = append(paths, strings.TrimSpace(item.Value))
+	}
+
+	return paths, nil
+}
+
+// displayPath returns the path relative to the workspace when it is within it
+func (r *fieldsFileResolver) displayPath(path string) string {
+	relativePath, err := filepath.Rel(r.action.Getenv("GITHUB_WORKSPACE"), path)
+	if err != nil || strings.HasPrefix(relativePath, "..") {
+		return path
+	}
+
+	return relativePath
+}
+
+// usesIncludes returns whether the fields config includes or extends any files
+func usesIncludes(node *yaml.Node) bool {
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == includeKey || node.Content[i].Value == extendsKey {
+				return true
+			}
+		}
+	}
+
+	for _, child := range node.Content {
+		if usesIncludes(child) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// mergeNodes returns the base node with the override merged in. Mappings are merged key
+// by key, anything else is replaced by the override.
+func mergeNodes(base *yaml.Node, override *yaml.Node) *yaml.Node {
+	if base.Kind != yaml.MappingNode || override.Kind != yaml.MappingNode {
+		return override
+	}
+
+	merged := &yaml.Node{Kind: yaml.MappingNode, Tag: base.Tag, Content: append([]*yaml.Node{}, base.Content...)}
+	for i := 0; i+1 < len(override.Content); i += 2 {
+		key, value := override.Content[i], override.Content[i+1]
+		if baseValue := toolbox.YamlMappingValue(merged, key.Value); baseValue != nil {
+			value = mergeNodes(baseValue, value)
+		}
+		toolbox.YamlSetMappingValue(merged, key, value)
+	}
+
+	return merged
+}
+
+// mergeSequences returns the items of the base list followed by those of the list. When
+// replaceByLabel is set, an item with the same label as a base item replaces it instead.
+func mergeSequences(base *yaml.Node, list *yaml.Node, replaceByLabel bool) *yaml.Node {
+	if base == nil || base.Kind != yaml.SequenceNode || list.Kind != yaml.SequenceNode {
+		return list
+	}
+
+	merged := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: append([]*yaml.Node{}, base.Content...)}
+	for _, item := range list.Content {
+		replaced := false
+		if label := toolbox.YamlMappingValue(item, "label"); replaceByLabel && label != nil {
+			for i, baseItem := range merged.Content {
+				if baseLabel := toolbox.YamlMappingValue(baseItem, "label"); baseLabel != nil && baseLabel.Value == label.Value {
+					merged.Content[i], replaced = item, true
+					break
+				}
+			}
+		}
+
+		if !replaced {
+			merged.Content = append(merged.Content, item)
+		}
+	}
+
+	return merged
+}
+
+// resolvePath returns the path resolved against the directory, unless it is already absolute
+func resolvePath(dir string, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+
+	return filepath.Join(dir, path)
+}
@@ -0,0 +1,152 @@
+package config_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/boasihq/interactive-inputs/internal/config"
+	"github.com/boasihq/interactive-inputs/internal/errors"
+	"github.com/boasihq/interactive-inputs/internal/fields"
+	githubactions "github.com/sethvargo/go-githubactions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_NewFromInputs_FieldsFile(t *testing.T) {
+	workspace := t.TempDir()
+	files := map[string]string{
+		"forms/plain.yaml":      "fields:\n  - label: name\n    properties:\n      type: text\n",
+		"forms/plain.json":      `{"fields": [{"label": "name", "properties": {"type": "text"}}]}`,
+		"forms/common.yaml":     "- label: reason\n  properties:\n    type: textarea\n    required: true\n- label: ticket\n  properties:\n    type: text\n",
+		"forms/base.yaml":       "fields:\n  - label: environment\n    properties:\n      type: select\n      choices: [staging, production]\n      defaultValue: staging\n  - $include: common.yaml\nrules:\n  - expression: fields[\"ticket\"] != \"\"\n",
+		"forms/deploy.yaml":     "extends: base.yaml\nfields:\n  - label: environment\n    properties:\n      defaultValue: production\n  - label: replicas\n    properties:\n      type: text\n",
+		"forms/group.yaml":      "fields:\n  - label: services\n    properties:\n      type: group\n      fields:\n        - $include: common.yaml\n",
+		"forms/cycle-a.yaml":    "extends: cycle-b.yaml\n",
+		"forms/cycle-b.yaml":    "fields:\n  - $include: cycle-a.yaml\n",
+		"forms/missing.yaml":    "fields:\n  - $include: nowhere.yaml\n",
+		"forms/bad-extend.yaml": "extends:\n  - {path: base.yaml}\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(workspace, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+
+	reasonField := fields.Field{Label: "reason", Properties: fields.FieldProperties{Type: "textarea", Required: true}}
+	ticketField := fields.Field{Label: "ticket", Properties: fields.FieldProperties{Type: "text"}}
+
+	tests := []struct {
+		name              string
+		interactive       string
+		interactiveFile   string
+		expectedFields    []fields.Field
+		expectedRuleCount int
+		expectedError     error
+		expectedOutput    string
+	}{
+		{
+			name:            "successful - plain fields file",
+			interactiveFile: "forms/plain.yaml",
+			expectedFields:  []fields.Field{{Label: "name", Properties: fields.FieldProperties{Type: "text"}}},
+		},
+		{
+			name:            "successful - JSON fields file",
+			interactiveFile: "forms/plain.json",
+			expectedFields:  []fields.Field{{Label: "name", Properties: fields.FieldProperties{Type: "text"}}},
+		},
+		{
+			name:            "successful - extends a base file, overriding a field and including shared fields",
+			interactiveFile: "forms/deploy.yaml",
+			expectedFields: []fields.Field{
+				{Label: "environment", Properties: fields.FieldProperties{
+					Type:         "select",
+					DefaultValue: "production",
+					Choices:      []fields.Choice{{Label: "staging", Value: "staging"}, {Label: "production", Value: "production"}},
+				}},
+				reasonField,
+				ticketField,
+				{Label: "replicas", Properties: fields.FieldProperties{Type: "text"}},
+			},
+			expectedRuleCount: 1,
+		},
+		{
+			name:           "successful - inline fields include a file relative to the workspace",
+			interactive:    "fields:\n  - $include: forms/common.yaml\n  - label: ticket\n    properties:\n      required: true\n",
+			expectedFields: []fields.Field{reasonField, {Label: "ticket", Properties: fields.FieldProperties{Type: "text", Required: true}}},
+		},
+		{
+			name:            "successful - group sub-fields include a file",
+			interactiveFile: "forms/group.yaml",
+			expectedFields: []fields.Field{
+				{Label: "services", Properties: fields.FieldProperties{Type: "group", Fields: []fields.Field{reasonField, ticketField}}},
+			},
+		},
+		{
+			name:            "failed - fields file not found",
+			interactiveFile: "forms/nowhere.yaml",
+			expectedError:   errors.ErrInvalidFieldsFileProvided,
+			expectedOutput:  "::error::Unable to read the fields file 'forms/nowhere.yaml': open " + filepath.Join(workspace, "forms/nowhere.yaml") + ": no such file or directory\n",
+		},
+		{
+			name:            "failed - included file not found",
+			interactiveFile: "forms/missing.yaml",
+			expectedError:   errors.ErrInvalidFieldsFileProvided,
+			expectedOutput:  "::error::Unable to read the fields file 'forms/nowhere.yaml' included by 'forms/missing.yaml': open " + filepath.Join(workspace, "forms/nowhere.yaml") + ": no such file or directory\n",
+		},
+		{
+			name:            "failed - files include each other",
+			interactiveFile: "forms/cycle-a.yaml",
+			expectedError:   errors.ErrCircularFieldsIncludeDetected,
+			expectedOutput:  "::error::Circular include detected: forms/cycle-a.yaml -> forms/cycle-b.yaml -> forms/cycle-a.yaml\n",
+		},
+		{
+			name:            "failed - extends isn't a path",
+			interactiveFile: "forms/bad-extend.yaml",
+			expectedError:   errors.ErrInvalidFieldsFileProvided,
+			expectedOutput:  "::error::Invalid extends provided in 'forms/bad-extend.yaml' (line 2) - it must be the path of a fields file, or a list of paths\n",
+		},
+		{
+			name:            "failed - fields provided inline and in a fields file",
+			interactive:     "fields:\n  - label: inline\n    properties:\n      type: text\n",
+			interactiveFile: "forms/plain.yaml",
+			expectedError:   errors.ErrInvalidFieldsFileProvided,
+			expectedOutput:  "::error::The 'interactive' and 'interactive-file' inputs can't both be provided - give the fields inline or in a fields file\n",
+		},
+		{
+			name:           "failed - include combined with other properties",
+			interactive:    "fields:\n  - $include: forms/common.yaml\n    label: extra\n",
+			expectedError:  errors.ErrInvalidFieldsFileProvided,
+			expectedOutput: "::error::The $include item of the 'interactive' input can't be combined with other properties (line 2) - override the included fields by label instead\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actionLog := bytes.NewBuffer(nil)
+			envMap := map[string]string{
+				"GITHUB_WORKSPACE":            workspace,
+				"INPUT_INTERACTIVE":           test.interactive,
+				"INPUT_INTERACTIVE-FILE":      test.interactiveFile,
+				"INPUT_GITHUB-TOKEN":          "github-secret-token",
+				"INPUT_SELFHOSTED-PUBLIC-URL": "https://example.com/inputs",
+			}
+			action := githubactions.New(
+				githubactions.WithWriter(actionLog),
+				githubactions.WithGetenv(func(key string) string { return envMap[key] }),
+			)
+
+			cfg, err := config.NewFromInputs(action)
+
+			if test.expectedError != nil {
+				assert.Equal(t, test.expectedError, err)
+				assert.Equal(t, "::debug::The timeout was not provided, will use the default timeout of 300 seconds\n"+test.expectedOutput, actionLog.String())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedFields, cfg.Fields.Fields)
+			assert.Len(t, cfg.Fields.Rules, test.expectedRuleCount)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/boasihq/interactive-inputs/internal/errors"
+	githubactions "github.com/sethvargo/go-githubactions"
+)
+
+// gateFromInputs returns, in gate mode, the checklist items that must all be ticked before
+// the gate is acknowledged. The items are given one per line, optionally as a markdown list.
+// A gate has no fields, so the fields inputs can't be used alongside it.
+func gateFromInputs(action *githubactions.Action, mode, interactiveInput, interactiveFileInput string) ([]string, error) {
+	var checklist []string
+	for _, item := range strings.Split(getInput(action, "gate-checklist"), "\n") {
+		item = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(item), "- "))
+		if item != "" {
+			checklist = append(checklist, item)
+		}
+	}
+
+	if mode != ModeGate {
+		if len(checklist) > 0 {
+			action.Errorf("The 'gate-checklist' input can only be used in %s mode", ModeGate)
+			return nil, errors.ErrInvalidGateSettingsProvided
+		}
+
+		return nil, nil
+	}
+
+	if strings.TrimSpace(interactiveInput) != "" || interactiveFileInput != "" {
+		action.Errorf("The 'interactive' and 'interactive-file' inputs can't be used in %s mode, as a gate has no fields", ModeGate)
+		return nil, errors.ErrInvalidGateSettingsProvided
+	}
+
+	return checklist, nil
+}
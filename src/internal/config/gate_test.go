@@ -0,0 +1,84 @@
+package config_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/boasihq/interactive-inputs/internal/config"
+	"github.com/boasihq/interactive-inputs/internal/errors"
+	githubactions "github.com/sethvargo/go-githubactions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_NewFromInputsGate(t *testing.T) {
+	tests := []struct {
+		name              string
+		envMap            map[string]string
+		expectedChecklist []string
+		expectedOutput    string
+		expectedError     error
+	}{
+		{
+			name:   "successful - gate without a checklist",
+			envMap: map[string]string{"INPUT_MODE": "gate"},
+		},
+		{
+			name: "successful - gate with a checklist written as a markdown list",
+			envMap: map[string]string{
+				"INPUT_MODE":           "gate",
+				"INPUT_GATE-CHECKLIST": "- Smoke tests passed\n\n- Release notes published\nOn-call engineer informed\n",
+			},
+			expectedChecklist: []string{"Smoke tests passed", "Release notes published", "On-call engineer informed"},
+		},
+		{
+			name: "failed - fields provided for a gate",
+			envMap: map[string]string{
+				"INPUT_MODE":        "gate",
+				"INPUT_INTERACTIVE": "fields:\n  - label: name\n    properties:\n      type: text\n",
+			},
+			expectedOutput: "::error::The 'interactive' and 'interactive-file' inputs can't be used in gate mode, as a gate has no fields\n",
+			expectedError:  errors.ErrInvalidGateSettingsProvided,
+		},
+		{
+			name: "failed - checklist outside of gate mode",
+			envMap: map[string]string{
+				"INPUT_INTERACTIVE":    "fields:\n  - label: name\n    properties:\n      type: text\n",
+				"INPUT_GATE-CHECKLIST": "Smoke tests passed",
+			},
+			expectedOutput: "::error::The 'gate-checklist' input can only be used in gate mode\n",
+			expectedError:  errors.ErrInvalidGateSettingsProvided,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actionLog := bytes.NewBuffer(nil)
+
+			envMap := map[string]string{
+				"INPUT_GITHUB-TOKEN":          "github-secret-token",
+				"INPUT_SELFHOSTED-PUBLIC-URL": "https://example.com/inputs",
+				"INPUT_TIMEOUT":               "300",
+			}
+			for key, value := range test.envMap {
+				envMap[key] = value
+			}
+
+			action := githubactions.New(
+				githubactions.WithWriter(actionLog),
+				githubactions.WithGetenv(func(key string) string { return envMap[key] }),
+			)
+
+			cfg, err := config.NewFromInputs(action)
+			if test.expectedError != nil {
+				assert.Equal(t, test.expectedError, err)
+				assert.Equal(t, test.expectedOutput, actionLog.String())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, config.ModeGate, cfg.Mode)
+			assert.Nil(t, cfg.Fields)
+			assert.Equal(t, test.expectedChecklist, cfg.GateChecklist)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/boasihq/interactive-inputs/internal/errors"
+	"github.com/boasihq/interactive-inputs/internal/fields"
+	"github.com/boasihq/interactive-inputs/internal/portal"
+	"github.com/boasihq/interactive-inputs/internal/toolbox"
+	githubactions "github.com/sethvargo/go-githubactions"
+)
+
+const (
+	// ModeForm is the default mode, where the portal is submitted or cancelled
+	ModeForm string = "form"
+
+	// ModeDecision is the mode where the portal is approved or rejected, with the
+	// decision and the reason given for it set as outputs
+	ModeDecision string = "decision"
+
+	// ModeGate is the mode where the portal has no fields and is acknowledged, once any
+	// checklist items have been ticked, with who acknowledged it and when set as outputs
+	ModeGate string = "gate"
+)
+
+const (
+	// DecisionReasonRequiredNever allows a decision to be made without a reason
+	DecisionReasonRequiredNever string = "false"
+
+	// DecisionReasonRequiredAlways requires a reason for both approving and rejecting
+	DecisionReasonRequiredAlways string = "true"
+
+	// DecisionReasonRequiredOnReject only requires a reason for rejecting
+	DecisionReasonRequiredOnReject string = "on-reject"
+)
+
+const (
+	// DecisionOnRejectFail fails the step when the portal is rejected
+	DecisionOnRejectFail string = "fail"
+
+	// DecisionOnRejectContinue lets the step succeed when the portal is rejected, so the
+	// workflow can branch on the decision output
+	DecisionOnRejectContinue string = "continue"
+)
+
+// ValidModes are the modes the portal can be operated in
+var ValidModes []string = []string{ModeForm, ModeDecision, ModeGate}
+
+// ValidDecisionReasonRequired are the values accepted for whether a decision needs a reason
+var ValidDecisionReasonRequired []string = []string{DecisionReasonRequiredNever, DecisionReasonRequiredAlways, DecisionReasonRequiredOnReject}
+
+// ValidDecisionOnReject are the values accepted for what happens when the portal is rejected
+var ValidDecisionOnReject []string = []string{DecisionOnRejectFail, DecisionOnRejectContinue}
+
+// modeFromInputs returns the mode the portal is operated in
+func modeFromInputs(action *githubactions.Action) (string, error) {
+	mode := toolbox.StringStandardisedToLower(getInput(action, "mode"))
+	if mode == "" {
+		mode = ModeForm
+	}
+
+	if !toolbox.StringInSlice(mode, ValidModes) {
+		action.Errorf("Invalid mode '%s' provided - must be one of: %s", mode, strings.Join(ValidModes, ", "))
+		return "", errors.ErrInvalidModeProvided
+	}
+
+	return mode, nil
+}
+
+// decisionFromInputs returns, in decision mode, whether a reason is required for the
+// decision and what happens when the portal is rejected. The decision outputs are set
+// alongside the field outputs, so no field can share their labels.
+func decisionFromInputs(action *githubactions.Action, mode string, interactiveFields *fields.Fields) (string, string, error) {
+	if mode != ModeDecision {
+		return "", "", nil
+	}
+
+	decisionReasonRequired, err := decisionInput(action, "decision-reason-required", DecisionReasonRequiredNever, ValidDecisionReasonRequired)
+	if err != nil {
+		return "", "", err
+	}
+
+	decisionOnReject, err := decisionInput(action, "decision-on-reject", DecisionOnRejectFail, ValidDecisionOnReject)
+	if err != nil {
+		return "", "", err
+	}
+
+	if interactiveFields != nil {
+		for _, field := range interactiveFields.Fields {
+			if field.Label == portal.DecisionFormKey || field.Label == portal.DecisionReasonFormKey || field.Label == portal.ApproversOutput {
+				action.Errorf("The field label '%s' can't be used in %s mode, as it is reserved for the decision outputs", field.Label, ModeDecision)
+				return "", "", errors.ErrInvalidDecisionSettingsProvided
+			}
+		}
+	}
+
+	return decisionReasonRequired, decisionOnReject, nil
+}
+
+// decisionInput returns the value of a decision mode input, or the default value when it
+// isn't provided
+func decisionInput(action *githubactions.Action, name string, defaultValue string, validValues []string) (string, error) {
+	value := toolbox.StringStandardisedToLower(getInput(action, name))
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	if !toolbox.StringInSlice(value, validValues) {
+		action.Errorf("Invalid %s '%s' provided - must be one of: %s", name, value, strings.Join(validValues, ", "))
+		return "", errors.ErrInvalidDecisionSettingsProvided
+	}
+
+	return value, nil
+}
+
+// DecisionReasonRequiredToApprove returns whether a reason must be given to approve the portal
+func (c *Config) DecisionReasonRequiredToApprove() bool {
+	return c.DecisionReasonRequired == DecisionReasonRequiredAlways
+}
+
+// DecisionReasonRequiredToReject returns whether a reason must be given to reject the portal
+func (c *Config) DecisionReasonRequiredToReject() bool {
+	return c.DecisionReasonRequired == DecisionReasonRequiredAlways || c.DecisionReasonRequired == DecisionReasonRequiredOnReject
+}
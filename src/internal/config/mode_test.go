@@ -0,0 +1,122 @@
+package config_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/boasihq/interactive-inputs/internal/config"
+	"github.com/boasihq/interactive-inputs/internal/errors"
+	githubactions "github.com/sethvargo/go-githubactions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_NewFromInputsMode(t *testing.T) {
+	tests := []struct {
+		name                           string
+		envMap                         map[string]string
+		expectedMode                   string
+		expectedDecisionReasonRequired string
+		expectedDecisionOnReject       string
+		expectedNoFields               bool
+		expectedOutput                 string
+		expectedError                  error
+	}{
+		{
+			name:         "successful - form mode by default",
+			envMap:       map[string]string{},
+			expectedMode: config.ModeForm,
+		},
+		{
+			name:                           "successful - decision mode with defaults",
+			envMap:                         map[string]string{"INPUT_MODE": "Decision"},
+			expectedMode:                   config.ModeDecision,
+			expectedDecisionReasonRequired: config.DecisionReasonRequiredNever,
+			expectedDecisionOnReject:       config.DecisionOnRejectFail,
+		},
+		{
+			name: "successful - decision mode requiring a reason to reject and continuing on reject",
+			envMap: map[string]string{
+				"INPUT_MODE":                     "decision",
+				"INPUT_DECISION-REASON-REQUIRED": "on-reject",
+				"INPUT_DECISION_ON_REJECT":       "continue",
+			},
+			expectedMode:                   config.ModeDecision,
+			expectedDecisionReasonRequired: config.DecisionReasonRequiredOnReject,
+			expectedDecisionOnReject:       config.DecisionOnRejectContinue,
+		},
+		{
+			name:                           "successful - decision mode without fields",
+			envMap:                         map[string]string{"INPUT_MODE": "decision", "INPUT_INTERACTIVE": ""},
+			expectedMode:                   config.ModeDecision,
+			expectedDecisionReasonRequired: config.DecisionReasonRequiredNever,
+			expectedDecisionOnReject:       config.DecisionOnRejectFail,
+			expectedNoFields:               true,
+		},
+		{
+			name:         "successful - decision inputs are ignored in form mode",
+			envMap:       map[string]string{"INPUT_DECISION-ON-REJECT": "explode"},
+			expectedMode: config.ModeForm,
+		},
+		{
+			name:           "failed - unknown mode",
+			envMap:         map[string]string{"INPUT_MODE": "vote"},
+			expectedOutput: "::error::Invalid mode 'vote' provided - must be one of: form, decision, gate\n",
+			expectedError:  errors.ErrInvalidModeProvided,
+		},
+		{
+			name:           "failed - unknown decision reason requirement",
+			envMap:         map[string]string{"INPUT_MODE": "decision", "INPUT_DECISION-REASON-REQUIRED": "sometimes"},
+			expectedOutput: "::error::Invalid decision-reason-required 'sometimes' provided - must be one of: false, true, on-reject\n",
+			expectedError:  errors.ErrInvalidDecisionSettingsProvided,
+		},
+		{
+			name:           "failed - unknown behaviour on reject",
+			envMap:         map[string]string{"INPUT_MODE": "decision", "INPUT_DECISION-ON-REJECT": "explode"},
+			expectedOutput: "::error::Invalid decision-on-reject 'explode' provided - must be one of: fail, continue\n",
+			expectedError:  errors.ErrInvalidDecisionSettingsProvided,
+		},
+		{
+			name: "failed - field label clashes with the decision outputs",
+			envMap: map[string]string{
+				"INPUT_MODE":        "decision",
+				"INPUT_INTERACTIVE": "fields:\n  - label: decision-reason\n    properties:\n      type: textarea\n",
+			},
+			expectedOutput: "::error::The field label 'decision-reason' can't be used in decision mode, as it is reserved for the decision outputs\n",
+			expectedError:  errors.ErrInvalidDecisionSettingsProvided,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actionLog := bytes.NewBuffer(nil)
+
+			envMap := map[string]string{
+				"INPUT_INTERACTIVE":           "fields:\n  - label: name\n    properties:\n      type: text\n",
+				"INPUT_GITHUB-TOKEN":          "github-secret-token",
+				"INPUT_SELFHOSTED-PUBLIC-URL": "https://example.com/inputs",
+				"INPUT_TIMEOUT":               "300",
+			}
+			for key, value := range test.envMap {
+				envMap[key] = value
+			}
+
+			action := githubactions.New(
+				githubactions.WithWriter(actionLog),
+				githubactions.WithGetenv(func(key string) string { return envMap[key] }),
+			)
+
+			cfg, err := config.NewFromInputs(action)
+			if test.expectedError != nil {
+				assert.Equal(t, test.expectedError, err)
+				assert.Equal(t, test.expectedOutput, actionLog.String())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedMode, cfg.Mode)
+			assert.Equal(t, test.expectedDecisionReasonRequired, cfg.DecisionReasonRequired)
+			assert.Equal(t, test.expectedDecisionOnReject, cfg.DecisionOnReject)
+			assert.Equal(t, test.expectedNoFields, cfg.Fields == nil)
+		})
+	}
+}
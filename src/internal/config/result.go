@@ -0,0 +1,26 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/boasihq/interactive-inputs/internal/errors"
+	githubactions "github.com/sethvargo/go-githubactions"
+)
+
+// resultFromInputs returns how long, in seconds, the read-only result page is served once
+// the portal has been completed, and whether the result is written to the job summary
+func resultFromInputs(action *githubactions.Action) (int, bool, error) {
+	var gracePeriod int
+
+	if gracePeriodInput := strings.TrimSpace(getInput(action, "result-grace-period")); gracePeriodInput != "" {
+		var err error
+		gracePeriod, err = strconv.Atoi(gracePeriodInput)
+		if err != nil || gracePeriod < 0 {
+			action.Errorf("Cannot convert the 'result-grace-period' input (%s) to a number of seconds", gracePeriodInput)
+			return 0, false, errors.ErrInvalidResultSettingsProvided
+		}
+	}
+
+	return gracePeriod, getInput(action, "job-summary") == "true", nil
+}
@@ -0,0 +1,80 @@
+package config_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/boasihq/interactive-inputs/internal/config"
+	"github.com/boasihq/interactive-inputs/internal/errors"
+	githubactions "github.com/sethvargo/go-githubactions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_NewFromInputsResult(t *testing.T) {
+	tests := []struct {
+		name                string
+		envMap              map[string]string
+		expectedGracePeriod int
+		expectedJobSummary  bool
+		expectedOutput      string
+		expectedError       error
+	}{
+		{
+			name:   "successful - the action finishes straight away by default",
+			envMap: map[string]string{},
+		},
+		{
+			name: "successful - result page served for a grace period and written to the job summary",
+			envMap: map[string]string{
+				"INPUT_RESULT-GRACE-PERIOD": "120",
+				"INPUT_JOB-SUMMARY":         "true",
+			},
+			expectedGracePeriod: 120,
+			expectedJobSummary:  true,
+		},
+		{
+			name:           "failed - grace period isn't a number",
+			envMap:         map[string]string{"INPUT_RESULT-GRACE-PERIOD": "2m"},
+			expectedOutput: "::error::Cannot convert the 'result-grace-period' input (2m) to a number of seconds\n",
+			expectedError:  errors.ErrInvalidResultSettingsProvided,
+		},
+		{
+			name:           "failed - grace period is negative",
+			envMap:         map[string]string{"INPUT_RESULT-GRACE-PERIOD": "-1"},
+			expectedOutput: "::error::Cannot convert the 'result-grace-period' input (-1) to a number of seconds\n",
+			expectedError:  errors.ErrInvalidResultSettingsProvided,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actionLog := bytes.NewBuffer(nil)
+
+			envMap := map[string]string{
+				"INPUT_INTERACTIVE":           "fields:\n  - label: name\n    properties:\n      type: text\n",
+				"INPUT_GITHUB-TOKEN":          "github-secret-token",
+				"INPUT_SELFHOSTED-PUBLIC-URL": "https://example.com/inputs",
+				"INPUT_TIMEOUT":               "300",
+			}
+			for key, value := range test.envMap {
+				envMap[key] = value
+			}
+
+			action := githubactions.New(
+				githubactions.WithWriter(actionLog),
+				githubactions.WithGetenv(func(key string) string { return envMap[key] }),
+			)
+
+			cfg, err := config.NewFromInputs(action)
+			if test.expectedError != nil {
+				assert.Equal(t, test.expectedError, err)
+				assert.Equal(t, test.expectedOutput, actionLog.String())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedGracePeriod, cfg.ResultGracePeriod)
+			assert.Equal(t, test.expectedJobSummary, cfg.JobSummary)
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package draft
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/boasihq/interactive-inputs/internal/errors"
+)
+
+// SessionCookieName is the name of the cookie holding the session a draft is saved for
+const SessionCookieName string = "interactive-inputs-session"
+
+// sharedDraftKey is the key the draft is saved under when it is shared between sessions
+const sharedDraftKey string = "shared"
+
+// sessionIdSize is the number of random bytes, and of signature bytes, in a session id
+const sessionIdSize int = 8
+
+// Draft holds the values entered into the portal that haven't been submitted yet
+type Draft struct {
+
+	// Values are the entered values, keyed by input name
+	Values url.Values
+
+	// SavedAt is when the draft was last saved
+	SavedAt time.Time
+}
+
+// NewStoreRequest holds everything needed to create a draft store
+type NewStoreRequest struct {
+
+	// Shared is true when every session saves to, and restores, the same draft, so
+	// collaborators can fill in the portal together
+	Shared bool
+}
+
+// Store holds the drafts saved for the sessions started with the portal, so the values
+// entered aren't lost when the portal is reloaded. Session ids are signed by the store, so
+// sessions are only kept once they save a draft, and viewing the portal doesn't use up any
+// memory. Drafts are kept for the lifetime of the store, which is the lifetime of the runner.
+// It is safe for concurrent use.
+type Store struct {
+	mu sync.Mutex
+
+	// shared is true when every session shares the same draft
+	shared bool
+
+	// secret signs the ids of the sessions started with the store, generated when the
+	// first session is started
+	secret []byte
+
+	// drafts are the saved drafts keyed by session id, or the shared draft key
+	drafts map[string]Draft
+}
+
+// NewStore returns a store without any sessions or drafts
+func NewStore(r *NewStoreRequest) *Store {
+	return &Store{
+		shared: r.Shared,
+		drafts: make(map[string]Draft),
+	}
+}
+
+// Shared returns whether every session shares the same draft
+func (s *Store) Shared() bool {
+	return s.shared
+}
+
+// StartSession returns the id of the session the request belongs to. When the request
+// doesn't belong to a session started with the store, a new session is started and its
+// id set as a cookie on the response. The cookie's path is left for the browser to default
+// to that of the portal, so it is sent with the portal's requests behind a path prefix.
+func (s *Store) StartSession(w http.ResponseWriter, r *http.Request) (string, error) {
+	if session := s.Session(r); session != "" {
+		return session, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.secret == nil {
+		secret := make([]byte, sha256.Size)
+		if _, err := rand.Read(secret); err != nil {
+			return "", err
+		}
+		s.secret = secret
+	}
+
+	id := make([]byte, sessionIdSize)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	session := hex.EncodeToString(append(id, s.signature(id)...))
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     SessionCookieName,
+		Value:    session,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	})
+
+	return session, nil
+}
+
+// Session returns the id of the session the request belongs to, or an empty string if it
+// doesn't belong to a session started with the store
+func (s *Store) Session(r *http.Request) string {
+	cookie, err := r.Cookie(SessionCookieName)
+	if err != nil {
+		return ""
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.started(cookie.Value) {
+		return ""
+	}
+
+	return cookie.Value
+}
+
+// Save replaces the draft of the session with the given values, returning the saved draft.
+// Only sessions started with the store can save a draft.
+func (s *Store) Save(session string, values url.Values) (Draft, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.started(session) {
+		return Draft{}, errors.ErrUnknownDraftSession
+	}
+
+	draft := Draft{Values: copyValues(values), SavedAt: time.Now().UTC()}
+	s.drafts[s.key(session)] = draft
+
+	return Draft{Values: copyValues(draft.Values), SavedAt: draft.SavedAt}, nil
+}
+
+// Load returns the draft of the session, and whether one has been saved. Sessions that
+// weren't started with the store don't have a draft.
+func (s *Store) Load(session string) (Draft, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	draft, ok := s.drafts[s.key(session)]
+	if !ok || !s.started(session) {
+		return Draft{}, false
+	}
+
+	draft.Values = copyValues(draft.Values)
+
+	return draft, true
+}
+
+// started returns whether the session was started with the store, i.e. its id is signed
+// by the store. The caller must hold mu.
+func (s *Store) started(session string) bool {
+	decoded, err := hex.DecodeString(session)
+	if err != nil || s.secret == nil || len(decoded) != 2*sessionIdSize {
+		return false
+	}
+
+	return hmac.Equal(decoded[sessionIdSize:], s.signature(decoded[:sessionIdSize]))
+}
+
+// signature returns the signature of the session id, which is as long as the id. The
+// caller must hold mu.
+func (s *Store) signature(id []byte) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write(id)
+
+	return mac.Sum(nil)[:sessionIdSize]
+}
+
+// key returns the key the draft of the session is saved under
+func (s *Store) key(session string) string {
+	if s.shared {
+		return sharedDraftKey
+	}
+
+	return session
+}
+
+// copyValues returns a copy of the values, so a saved draft can't be changed by its callers
+func copyValues(values url.Values) url.Values {
+	copied := make(url.Values, len(values))
+	for key, value := range values {
+		copied[key] = append([]string(nil), value...)
+	}
+
+	return copied
+}
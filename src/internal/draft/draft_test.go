@@ -0,0 +1,130 @@
+package draft_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/boasihq/interactive-inputs/internal/draft"
+	"github.com/boasihq/interactive-inputs/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// startSession starts a session with the store, returning its id and a request that
+// belongs to it
+func startSession(t *testing.T, store *draft.Store) (string, *http.Request) {
+	w := httptest.NewRecorder()
+	session, err := store.StartSession(w, httptest.NewRequest(http.MethodGet, "/runner/", nil))
+	assert.NoError(t, err)
+
+	r := httptest.NewRequest(http.MethodGet, "/runner/", nil)
+	for _, cookie := range w.Result().Cookies() {
+		r.AddCookie(cookie)
+	}
+
+	return session, r
+}
+
+func TestStore_StartSession(t *testing.T) {
+	store := draft.NewStore(&draft.NewStoreRequest{})
+
+	w := httptest.NewRecorder()
+	session, err := store.StartSession(w, httptest.NewRequest(http.MethodGet, "/runner/", nil))
+	assert.NoError(t, err)
+	assert.Len(t, session, 32)
+
+	cookies := w.Result().Cookies()
+	assert.Len(t, cookies, 1)
+	assert.Equal(t, draft.SessionCookieName, cookies[0].Name)
+	assert.Equal(t, session, cookies[0].Value)
+	assert.Empty(t, cookies[0].Path)
+	assert.True(t, cookies[0].HttpOnly)
+
+	// the session is reused by later requests, without setting the cookie again
+	r := httptest.NewRequest(http.MethodGet, "/runner/", nil)
+	r.AddCookie(cookies[0])
+
+	w = httptest.NewRecorder()
+	resumedSession, err := store.StartSession(w, r)
+	assert.NoError(t, err)
+	assert.Equal(t, session, resumedSession)
+	assert.Empty(t, w.Result().Cookies())
+	assert.Equal(t, session, store.Session(r))
+
+	// sessions that weren't started with the store are ignored
+	r = httptest.NewRequest(http.MethodGet, "/runner/", nil)
+	r.AddCookie(&http.Cookie{Name: draft.SessionCookieName, Value: "made-up"})
+	assert.Empty(t, store.Session(r))
+
+	w = httptest.NewRecorder()
+	newSession, err := store.StartSession(w, r)
+	assert.NoError(t, err)
+	assert.NotEqual(t, "made-up", newSession)
+	assert.Len(t, w.Result().Cookies(), 1)
+}
+
+func TestStore_SaveAndLoad(t *testing.T) {
+	tests := []struct {
+		name                string
+		shared              bool
+		expectedOtherValues url.Values
+	}{
+		{
+			name:                "each session has its own draft",
+			shared:              false,
+			expectedOtherValues: nil,
+		},
+		{
+			name:                "every session shares the same draft",
+			shared:              true,
+			expectedOtherValues: url.Values{"name": {"alice"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			store := draft.NewStore(&draft.NewStoreRequest{Shared: test.shared})
+			assert.Equal(t, test.shared, store.Shared())
+
+			session, _ := startSession(t, store)
+			otherSession, _ := startSession(t, store)
+
+			_, ok := store.Load(session)
+			assert.False(t, ok)
+
+			values := url.Values{"name": {"alice"}}
+			savedDraft, err := store.Save(session, values)
+			assert.NoError(t, err)
+			assert.Equal(t, values, savedDraft.Values)
+
+			// the saved draft isn't changed by changes to the values it was saved from
+			values.Set("name", "bob")
+
+			saved, ok := store.Load(session)
+			assert.True(t, ok)
+			assert.Equal(t, url.Values{"name": {"alice"}}, saved.Values)
+			assert.False(t, saved.SavedAt.IsZero())
+
+			otherDraft, _ := store.Load(otherSession)
+			assert.Equal(t, test.expectedOtherValues, otherDraft.Values)
+		})
+	}
+}
+
+func TestStore_SaveUnknownSession(t *testing.T) {
+	store := draft.NewStore(&draft.NewStoreRequest{Shared: true})
+
+	_, err := store.Save("made-up", url.Values{"name": {"alice"}})
+	assert.ErrorIs(t, err, errors.ErrUnknownDraftSession)
+
+	_, ok := store.Load("made-up")
+	assert.False(t, ok)
+
+	// sessions started with another store aren't signed by this one
+	otherSession, _ := startSession(t, draft.NewStore(&draft.NewStoreRequest{Shared: true}))
+	_, _ = startSession(t, store)
+
+	_, err = store.Save(otherSession, url.Values{"name": {"alice"}})
+	assert.ErrorIs(t, err, errors.ErrUnknownDraftSession)
+}
@@ -58,4 +58,103 @@ var (
 
 	// ErrSelfHostedPublicURLMissing is returned when self-hosted mode is enabled but no public url was supplied
 	ErrSelfHostedPublicURLMissing = errors.New("SelfHostedPublicURLMissing")
+
+	// ErrInvalidChoiceProvided is returned when a choice is provided without a label or value
+	ErrInvalidChoiceProvided = errors.New("InvalidChoiceProvided")
+
+	// ErrDuplicateChoiceValueDetected is returned when the same choice value is detected more than
+	// once within a field's choices
+	ErrDuplicateChoiceValueDetected = errors.New("DuplicateChoiceValueDetected")
+
+	// ErrDuplicateStepLabelDetected is returned when the same step label is detected in the input data
+	ErrDuplicateStepLabelDetected = errors.New("DuplicateStepLabelDetected")
+
+	// ErrInvalidStepReferenceProvided is returned when a field references a step that has not been defined
+	ErrInvalidStepReferenceProvided = errors.New("InvalidStepReferenceProvided")
+
+	// ErrInvalidTemplateProvided is returned when a templated field property cannot be parsed
+	ErrInvalidTemplateProvided = errors.New("InvalidTemplateProvided")
+
+	// ErrInvalidGroupItemLimitsProvided is returned when the minimum and maximum number of items
+	// provided for a group field are invalid
+	ErrInvalidGroupItemLimitsProvided = errors.New("InvalidGroupItemLimitsProvided")
+
+	// ErrInvalidSchemaProvided is returned when the JSON Schema provided for a structured field
+	// cannot be loaded or compiled
+	ErrInvalidSchemaProvided = errors.New("InvalidSchemaProvided")
+
+	// ErrInvalidDefaultValueProvided is returned when a field's default value is not valid for the field
+	ErrInvalidDefaultValueProvided = errors.New("InvalidDefaultValueProvided")
+
+	// ErrInvalidRuleProvided is returned when a validation rule can't be compiled or references
+	// fields that don't exist
+	ErrInvalidRuleProvided = errors.New("InvalidRuleProvided")
+
+	// ErrInvalidNumberPropertiesProvided is returned when the bounds, step or precision provided
+	// for a number field are invalid
+	ErrInvalidNumberPropertiesProvided = errors.New("InvalidNumberPropertiesProvided")
+
+	// ErrInvalidConfirmPhraseProvided is returned when a confirm field is provided without a phrase
+	ErrInvalidConfirmPhraseProvided = errors.New("InvalidConfirmPhraseProvided")
+
+	// ErrInvalidDisplayContentProvided is returned when a display-only field is missing the content
+	// or files it should display
+	ErrInvalidDisplayContentProvided = errors.New("InvalidDisplayContentProvided")
+
+	// ErrInvalidRegionProvided is returned when the regions of a region field are missing, duplicated
+	// or the map file provided can't be found
+	ErrInvalidRegionProvided = errors.New("InvalidRegionProvided")
+
+	// ErrInvalidPropertyProvided is returned when a property in the fields config isn't known, or
+	// isn't supported by the type of the field it is provided for
+	ErrInvalidPropertyProvided = errors.New("InvalidPropertyProvided")
+
+	// ErrInvalidFieldsFileProvided is returned when a fields file, or a file it includes or extends,
+	// can't be read or doesn't contain a valid fields config
+	ErrInvalidFieldsFileProvided = errors.New("InvalidFieldsFileProvided")
+
+	// ErrCircularFieldsIncludeDetected is returned when fields files include or extend each other
+	// in a cycle
+	ErrCircularFieldsIncludeDetected = errors.New("CircularFieldsIncludeDetected")
+
+	// ErrInvalidModeProvided is returned when the mode provided isn't one of the supported modes
+	ErrInvalidModeProvided = errors.New("InvalidModeProvided")
+
+	// ErrInvalidDecisionSettingsProvided is returned when the decision mode inputs are invalid, or a
+	// field's label clashes with the decision outputs
+	ErrInvalidDecisionSettingsProvided = errors.New("InvalidDecisionSettingsProvided")
+
+	// ErrInvalidGateSettingsProvided is returned when the gate mode inputs are invalid, or fields
+	// are provided for a gate
+	ErrInvalidGateSettingsProvided = errors.New("InvalidGateSettingsProvided")
+
+	// ErrInvalidResultSettingsProvided is returned when the grace period the result page is
+	// served for isn't a number of seconds
+	ErrInvalidResultSettingsProvided = errors.New("InvalidResultSettingsProvided")
+
+	// ErrInvalidApprovalSettingsProvided is returned when the approval quorum inputs are invalid
+	ErrInvalidApprovalSettingsProvided = errors.New("InvalidApprovalSettingsProvided")
+
+	// ErrAlreadyApproved is returned when an identity approves a request it has already approved
+	ErrAlreadyApproved = errors.New("AlreadyApproved")
+
+	// ErrAlreadyDecided is returned when a decision is made after the approval quorum has been
+	// reached, or the request has been vetoed
+	ErrAlreadyDecided = errors.New("AlreadyDecided")
+
+	// ErrUnableToCheckTeamMembership is returned when the GitHub API can't be used to check
+	// whether a user is a member of a team
+	ErrUnableToCheckTeamMembership = errors.New("UnableToCheckTeamMembership")
+
+	// ErrInvalidPermissionProvided is returned when the teams a field, or choice, is limited to
+	// are invalid, or the people using the portal can't be identified to enforce them
+	ErrInvalidPermissionProvided = errors.New("InvalidPermissionProvided")
+
+	// ErrInvalidSecretProvided is returned when a sub-field of a group field is secret, as its
+	// value can't be masked within the output of the group
+	ErrInvalidSecretProvided = errors.New("InvalidSecretProvided")
+
+	// ErrUnknownDraftSession is returned when a draft is saved for a session that wasn't
+	// started by the portal
+	ErrUnknownDraftSession = errors.New("UnknownDraftSession")
 )
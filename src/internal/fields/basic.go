@@ -0,0 +1,140 @@
+package fields
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/boasihq/interactive-inputs/internal/toolbox"
+)
+
+var (
+
+	// textFieldType is a single line of free text
+	textFieldType = FieldType{
+		Name:       "text",
+		Properties: []string{"maxLength", "placeholder", "editableBy", "secret"},
+		Template:   "field-text",
+		Groupable:  true,
+		Validate:   validateSubmittedValues(checkTextLength),
+		Output:     encodeSubmittedValues(nil),
+	}
+
+	// textareaFieldType is multiple lines of free text
+	textareaFieldType = FieldType{
+		Name:       "textarea",
+		Properties: []string{"maxLength", "placeholder", "editableBy"},
+		Template:   "field-textarea",
+		Groupable:  true,
+		Validate:   validateSubmittedValues(checkTextLength),
+		Output:     encodeSubmittedValues(nil),
+	}
+
+	// booleanFieldType is a choice between true and false
+	booleanFieldType = FieldType{
+		Name:       "boolean",
+		Properties: []string{"editableBy"},
+		Template:   "field-boolean",
+		Groupable:  true,
+		Validate:   validateSubmittedValues(checkBoolean),
+		Output:     encodeSubmittedValues(nil),
+		TypedValue: func(field Field, form url.Values) interface{} {
+			if boolean, err := strconv.ParseBool(strings.TrimSpace(form.Get(field.Label))); err == nil {
+				return boolean
+			}
+			return nil
+		},
+	}
+
+	// selectFieldType is a single choice from a list of options
+	selectFieldType = FieldType{
+		Name:       "select",
+		Properties: []string{"choices", "disableAutoCopySelection", "editableBy"},
+		Template:   "field-select",
+		Groupable:  true,
+		Validate:   validateSubmittedValues(checkSingleChoice),
+		Output:     encodeSubmittedValues(nil),
+	}
+
+	// multiselectFieldType is any number of choices from a list of options
+	multiselectFieldType = FieldType{
+		Name:       "multiselect",
+		Properties: []string{"choices", "disableAutoCopySelection", "editableBy"},
+		Template:   "field-multiselect",
+		Groupable:  true,
+		Validate:   validateSubmittedValues(checkChoices),
+		Output:     encodeSubmittedValues(nil),
+		TypedValue: func(field Field, form url.Values) interface{} {
+			values, ok := form[field.Label]
+			if !ok {
+				return nil
+			}
+
+			selected := make([]string, 0, len(values))
+			for _, value := range values {
+				if value != "" {
+					selected = append(selected, value)
+				}
+			}
+			return selected
+		},
+	}
+
+	// fileFieldType is a single uploaded file. Uploads are handled by the upload endpoint
+	// and held in the runner's cache, which the output points to.
+	fileFieldType = FieldType{
+		Name:       "file",
+		Properties: []string{"acceptedFileTypes"},
+		Template:   "field-file",
+	}
+
+	// multifileFieldType is any number of uploaded files, handled in the same way as a file field
+	multifileFieldType = FieldType{
+		Name:       "multifile",
+		Properties: []string{"acceptedFileTypes"},
+		Template:   "field-file",
+	}
+)
+
+// checkTextLength returns a message if the text is longer than the field's maximum length
+func checkTextLength(field Field, values []string) string {
+	if field.Properties.MaxLength > 0 && utf8.RuneCountInString(values[0]) > field.Properties.MaxLength {
+		return fmt.Sprintf("Must be at most %d characters long", field.Properties.MaxLength)
+	}
+
+	return ""
+}
+
+// checkBoolean returns a message if the value isn't true or false
+func checkBoolean(_ Field, values []string) string {
+	if values[0] != "true" && values[0] != "false" {
+		return "Must be either true or false"
+	}
+
+	return ""
+}
+
+// checkSingleChoice returns a message if more than one choice is selected, or any of
+// the values aren't one of the field's choices
+func checkSingleChoice(field Field, values []string) string {
+	if len(values) > 1 {
+		return "Only one option can be selected"
+	}
+
+	return checkChoices(field, values)
+}
+
+// checkChoices returns a message if any of the values aren't one of the field's choices
+// that can be selected
+func checkChoices(field Field, values []string) string {
+	choiceValues := field.Properties.ChoiceValues()
+	for _, value := range values {
+		if !toolbox.StringInSlice(value, choiceValues) {
+			return fmt.Sprintf("'%s' is not one of the available options", value)
+		}
+	}
+
+	return ""
+}
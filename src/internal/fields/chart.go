@@ -0,0 +1,187 @@
+package fields
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/boasihq/interactive-inputs/internal/toolbox"
+	"gopkg.in/yaml.v3"
+)
+
+// ChartSeries is a named set of values plotted on a chart. Values that are missing
+// from the data are nil, so the chart shows a gap.
+type ChartSeries struct {
+	Name string     `json:"name"`
+	Data []*float64 `json:"data"`
+}
+
+// ChartContent holds the data plotted by a chart field
+type ChartContent struct {
+	Name       string        `json:"-"`
+	Type       string        `json:"type"`
+	Categories []string      `json:"categories"`
+	Series     []ChartSeries `json:"series"`
+}
+
+// JSON returns the chart encoded as JSON, so it can be passed to the chart component
+func (c ChartContent) JSON() (string, error) {
+	encoded, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+
+	return string(encoded), nil
+}
+
+// renderChart reads the data file of the chart field, and picks out the x-axis
+// categories and the series to plot
+func renderChart(field Field) (interface{}, error) {
+	content, truncated, err := readDisplayFile(field.Properties.File)
+	if err != nil {
+		return nil, err
+	}
+
+	if truncated {
+		return nil, fmt.Errorf("the file is larger than %d KiB", maxDisplayFileSize/1024)
+	}
+
+	var columns []string
+	var rows []map[string]string
+	if strings.ToLower(filepath.Ext(field.Properties.File)) == ".csv" {
+		columns, rows, err = readCSVTable(content)
+	} else {
+		columns, rows, err = readJSONTable(content)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("the file doesn't contain any columns")
+	}
+
+	xAxis := field.Properties.XAxis
+	if xAxis == "" {
+		xAxis = columns[0]
+	}
+	if !toolbox.StringInSlice(xAxis, columns) {
+		return nil, fmt.Errorf("the x-axis column '%s' isn't in the file", xAxis)
+	}
+
+	seriesColumns := field.Properties.Series
+	if len(seriesColumns) == 0 {
+		for _, column := range columns {
+			if column != xAxis {
+				seriesColumns = append(seriesColumns, column)
+			}
+		}
+	}
+
+	chart := &ChartContent{
+		Name:       displayFileName(field.Properties.File),
+		Type:       field.Properties.ChartType,
+		Categories: make([]string, 0, len(rows)),
+	}
+
+	for _, row := range rows {
+		chart.Categories = append(chart.Categories, row[xAxis])
+	}
+
+	for _, column := range seriesColumns {
+		if !toolbox.StringInSlice(column, columns) {
+			return nil, fmt.Errorf("the series column '%s' isn't in the file", column)
+		}
+
+		series := ChartSeries{Name: column, Data: make([]*float64, 0, len(rows))}
+		for i, row := range rows {
+			value := strings.TrimSpace(row[column])
+			if value == "" {
+				series.Data = append(series.Data, nil)
+				continue
+			}
+
+			number, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("the value '%s' of column '%s' in row %d isn't a number", value, column, i+1)
+			}
+			series.Data = append(series.Data, &number)
+		}
+
+		chart.Series = append(chart.Series, series)
+	}
+
+	return chart, nil
+}
+
+// readCSVTable reads CSV data, using the first record as the column names
+func readCSVTable(content string) ([]string, []map[string]string, error) {
+	records, err := csv.NewReader(strings.NewReader(content)).ReadAll()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, nil, nil
+	}
+
+	columns := records[0]
+	rows := make([]map[string]string, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make(map[string]string, len(columns))
+		for i, column := range columns {
+			row[column] = record[i]
+		}
+		rows = append(rows, row)
+	}
+
+	return columns, rows, nil
+}
+
+// readJSONTable reads a JSON array of objects. The columns are the keys of the objects,
+// in the order they first appear in the file.
+func readJSONTable(content string) ([]string, []map[string]string, error) {
+	// JSON is decoded as YAML so the order of the keys is kept
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &document); err != nil {
+		return nil, nil, err
+	}
+
+	if len(document.Content) == 0 {
+		return nil, nil, nil
+	}
+
+	list := document.Content[0]
+	if list.Kind != yaml.SequenceNode {
+		return nil, nil, fmt.Errorf("the file must contain an array of objects")
+	}
+
+	var columns []string
+	rows := make([]map[string]string, 0, len(list.Content))
+	for i, item := range list.Content {
+		if item.Kind != yaml.MappingNode {
+			return nil, nil, fmt.Errorf("item %d of the array isn't an object", i+1)
+		}
+
+		row := make(map[string]string, len(item.Content)/2)
+		for j := 0; j+1 < len(item.Content); j += 2 {
+			key, value := item.Content[j].Value, item.Content[j+1]
+			if value.Kind != yaml.ScalarNode {
+				return nil, nil, fmt.Errorf("the value of '%s' in item %d must be a number or string", key, i+1)
+			}
+
+			if !toolbox.StringInSlice(key, columns) {
+				columns = append(columns, key)
+			}
+			if value.Tag != "!!null" {
+				row[key] = value.Value
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return columns, rows, nil
+}
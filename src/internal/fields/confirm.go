@@ -0,0 +1,63 @@
+package fields
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/boasihq/interactive-inputs/internal/errors"
+	"github.com/sethvargo/go-githubactions"
+)
+
+// confirmFieldType is a phrase that must be typed exactly before the form can be
+// submitted. It guards the submission, so it doesn't have an output.
+var confirmFieldType = FieldType{
+	Name:       "confirm",
+	Properties: []string{"phrase"},
+	Template:   "field-confirm",
+	Normalise:  normaliseConfirmField,
+	Validate: func(field Field, form url.Values, data TemplateData) FieldErrors {
+		var fieldErrors FieldErrors = make(FieldErrors)
+		if message := field.validateConfirmation(form.Get(field.Label), data); message != "" {
+			fieldErrors[field.Label] = message
+		}
+		return fieldErrors
+	},
+}
+
+// normaliseConfirmField makes sure a confirm field has a phrase that can be expanded
+func normaliseConfirmField(field *Field, action *githubactions.Action) error {
+	if strings.TrimSpace(field.Properties.Phrase) == "" {
+		action.Errorf("No phrase provided for confirm field '%s'", field.Label)
+		return errors.ErrInvalidConfirmPhraseProvided
+	}
+
+	if err := ValidateTemplate(field.Properties.Phrase); err != nil {
+		action.Errorf("Invalid phrase template provided for field '%s': %s", field.Label, err)
+		return errors.ErrInvalidTemplateProvided
+	}
+
+	// the phrase must always be typed for the confirmation to be meaningful
+	field.Properties.Required = true
+
+	return nil
+}
+
+// validateConfirmation returns a message describing why the typed value doesn't match
+// the confirm field's phrase, or an empty string if it matches exactly.
+func (field Field) validateConfirmation(value string, data TemplateData) string {
+	phrase, err := ExpandTemplate(field.Properties.Phrase, data)
+	if err != nil {
+		return "Unable to determine the confirmation phrase"
+	}
+
+	if value == "" {
+		return fmt.Sprintf("Type '%s' to confirm", phrase)
+	}
+
+	if value != phrase {
+		return fmt.Sprintf("Must exactly match '%s'", phrase)
+	}
+
+	return ""
+}
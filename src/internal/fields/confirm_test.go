@@ -0,0 +1,99 @@
+package fields_test
+
+import (
+	"bytes"
+	"net/url"
+	"testing"
+
+	"github.com/boasihq/interactive-inputs/internal/fields"
+	"github.com/sethvargo/go-githubactions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalStringIntoValidFieldsStruct_Confirm(t *testing.T) {
+	tests := []struct {
+		name           string
+		fieldsString   string
+		expectedError  bool
+		expectedOutput string
+	}{
+		{
+			name:          "success - templated phrase",
+			fieldsString:  "fields:\n  - label: confirm-delete\n    properties:\n      type: confirm\n      phrase: '{{ .GitHub.Repository }}'\n",
+			expectedError: false,
+		},
+		{
+			name:           "failed - missing phrase",
+			fieldsString:   "fields:\n  - label: confirm-delete\n    properties:\n      type: confirm\n",
+			expectedError:  true,
+			expectedOutput: "::error::No phrase provided for confirm field 'confirm-delete'\n",
+		},
+		{
+			name:           "failed - invalid phrase template",
+			fieldsString:   "fields:\n  - label: confirm-delete\n    properties:\n      type: confirm\n      phrase: '{{ .GitHub.Repository'\n",
+			expectedError:  true,
+			expectedOutput: "::error::Invalid phrase template provided for field 'confirm-delete': template: property:1: unclosed action\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actionLog := bytes.NewBuffer(nil)
+			action := githubactions.New(githubactions.WithWriter(actionLog))
+
+			result, err := fields.MarshalStringIntoValidFieldsStruct(tt.fieldsString, action)
+
+			assert.Equal(t, tt.expectedOutput, actionLog.String())
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, result.Fields[0].Properties.Required)
+		})
+	}
+}
+
+func TestFields_ValidateConfirm(t *testing.T) {
+	f := &fields.Fields{
+		Fields: []fields.Field{
+			{Label: "environment", Properties: fields.FieldProperties{Type: "text"}},
+			{Label: "confirm-delete", Properties: fields.FieldProperties{Type: "confirm", Required: true, Phrase: `{{ .GitHub.Repository }}/{{ answer "environment" }}`}},
+		},
+	}
+	data := fields.TemplateData{GitHub: &githubactions.GitHubContext{Repository: "acme/app"}}
+
+	tests := []struct {
+		name           string
+		form           url.Values
+		expectedErrors fields.FieldErrors
+	}{
+		{
+			name:           "phrase typed exactly",
+			form:           url.Values{"environment": {"production"}, "confirm-delete": {"acme/app/production"}},
+			expectedErrors: fields.FieldErrors{},
+		},
+		{
+			name:           "phrase missing from a crafted submission",
+			form:           url.Values{"environment": {"production"}},
+			expectedErrors: fields.FieldErrors{"confirm-delete": "Type 'acme/app/production' to confirm"},
+		},
+		{
+			name:           "phrase typed with different case",
+			form:           url.Values{"environment": {"production"}, "confirm-delete": {"ACME/app/production"}},
+			expectedErrors: fields.FieldErrors{"confirm-delete": "Must exactly match 'acme/app/production'"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedErrors, f.Validate(tt.form, "", data))
+		})
+	}
+
+	outputs, err := f.Outputs(url.Values{"environment": {"production"}, "confirm-delete": {"acme/app/production"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []fields.Output{{Label: "environment", Value: "production"}}, outputs)
+}
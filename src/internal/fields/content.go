@@ -0,0 +1,368 @@
+package fields
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"html/template"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+// maxDisplayFileSize is the maximum number of bytes of a file that will be displayed
+// in the portal, so large files (i.e. verbose plans) don't make the portal unusable
+const maxDisplayFileSize int64 = 512 * 1024
+
+// markdownRenderer converts markdown to HTML. Raw HTML within the markdown and links
+// with dangerous URLs are omitted.
+var markdownRenderer = goldmark.New(goldmark.WithExtensions(extension.GFM))
+
+// markdownPolicy sanitises the HTML rendered from markdown, so only the elements and
+// attributes expected in user generated content are kept. Links to other sites are
+// opened in a new tab so the portal isn't navigated away from.
+var markdownPolicy = bluemonday.UGCPolicy().
+	RequireNoFollowOnLinks(true).
+	AddTargetBlankToFullyQualifiedLinks(true)
+
+// ansiSequencePattern matches ANSI escape sequences, capturing the parameters and
+// final byte of CSI sequences so colours can be converted
+var ansiSequencePattern = regexp.MustCompile(`\x1b\[([0-9;]*)([A-Za-z])|\x1b[^\[]`)
+
+// ansiColours is the palette used for the standard (30-37) and bright (90-97) ANSI colours
+var ansiColours = [16]string{
+	"#1f2937", "#dc2626", "#16a34a", "#ca8a04", "#2563eb", "#c026d3", "#0891b2", "#e5e7eb",
+	"#6b7280", "#f87171", "#4ade80", "#facc15", "#60a5fa", "#e879f9", "#22d3ee", "#ffffff",
+}
+
+// DiffRow is a row of a side-by-side diff. Numbers are zero when the side of the row
+// has no line, i.e. the From side of an inserted line.
+type DiffRow struct {
+	Kind       string
+	FromNumber int
+	FromLine   string
+	ToNumber   int
+	ToLine     string
+}
+
+// FileContent holds the contents of a file displayed in the portal
+type FileContent struct {
+	Name      string
+	Content   template.HTML
+	Truncated bool
+}
+
+// DiffContent holds the comparison of two files displayed in the portal
+type DiffContent struct {
+	FromName  string
+	ToName    string
+	Rows      []DiffRow
+	Truncated bool
+}
+
+// RenderMarkdown converts the markdown to sanitised HTML
+func RenderMarkdown(markdown string) (template.HTML, error) {
+	var rendered bytes.Buffer
+	err := markdownRenderer.Convert([]byte(markdown), &rendered)
+	if err != nil {
+		return "", err
+	}
+
+	return template.HTML(markdownPolicy.SanitizeReader(&rendered).String()), nil
+}
+
+// renderFilePreview reads the file, converting any ANSI colours to HTML
+func renderFilePreview(field Field) (interface{}, error) {
+	content, truncated, err := readDisplayFile(field.Properties.File)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileContent{
+		Name:      displayFileName(field.Properties.File),
+		Content:   ansiToHTML(content),
+		Truncated: truncated,
+	}, nil
+}
+
+// renderDiff compares the lines of the field's files
+func renderDiff(field Field) (interface{}, error) {
+	fromContent, fromTruncated, err := readDisplayFile(field.Properties.FromFile)
+	if err != nil {
+		return nil, err
+	}
+
+	toContent, toTruncated, err := readDisplayFile(field.Properties.ToFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DiffContent{
+		FromName:  displayFileName(field.Properties.FromFile),
+		ToName:    displayFileName(field.Properties.ToFile),
+		Rows:      diffLines(stripANSI(fromContent), stripANSI(toContent)),
+		Truncated: fromTruncated || toTruncated,
+	}, nil
+}
+
+// readDisplayFile reads the file up to the maximum display size, returning whether
+// the contents were truncated
+func readDisplayFile(path string) (string, bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", false, err
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, maxDisplayFileSize+1))
+	if err != nil {
+		return "", false, err
+	}
+
+	if int64(len(content)) > maxDisplayFileSize {
+		return string(content[:maxDisplayFileSize]), true, nil
+	}
+
+	return string(content), false, nil
+}
+
+// displayFileName returns the name of the file to show the user, relative to the
+// workspace when the file is within it
+func displayFileName(path string) string {
+	workspace := strings.TrimSuffix(os.Getenv("GITHUB_WORKSPACE"), "/")
+	if workspace != "" && strings.HasPrefix(path, workspace+"/") {
+		return strings.TrimPrefix(path, workspace+"/")
+	}
+
+	return path
+}
+
+// diffLines returns the rows of a side-by-side diff of the two texts
+func diffLines(from string, to string) []DiffRow {
+	fromLines := splitLines(from)
+	toLines := splitLines(to)
+
+	var rows []DiffRow
+	matcher := difflib.NewMatcherWithJunk(fromLines, toLines, false, nil)
+
+	for _, opCode := range matcher.GetOpCodes() {
+		switch opCode.Tag {
+		case 'e':
+			for i := 0; i < opCode.I2-opCode.I1; i++ {
+				rows = append(rows, DiffRow{
+					Kind:       "equal",
+					FromNumber: opCode.I1 + i + 1, FromLine: fromLines[opCode.I1+i],
+					ToNumber: opCode.J1 + i + 1, ToLine: toLines[opCode.J1+i],
+				})
+			}
+
+		default:
+			// pair up replaced lines, with the remainder shown as deletions or insertions
+			fromCount, toCount := opCode.I2-opCode.I1, opCode.J2-opCode.J1
+			for i := 0; i < fromCount || i < toCount; i++ {
+				row := DiffRow{Kind: "replace"}
+				if i < fromCount {
+					row.FromNumber, row.FromLine = opCode.I1+i+1, fromLines[opCode.I1+i]
+				}
+				if i < toCount {
+					row.ToNumber, row.ToLine = opCode.J1+i+1, toLines[opCode.J1+i]
+				}
+
+				switch {
+				case row.ToNumber == 0:
+					row.Kind = "delete"
+				case row.FromNumber == 0:
+					row.Kind = "insert"
+				}
+
+				rows = append(rows, row)
+			}
+		}
+	}
+
+	return rows
+}
+
+// splitLines splits the text into lines, ignoring the trailing newline
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// stripANSI removes ANSI escape sequences from the text
+func stripANSI(text string) string {
+	return ansiSequencePattern.ReplaceAllString(text, "")
+}
+
+// ansiToHTML escapes the text and converts its ANSI colour and style sequences (SGR) to
+// styled spans. Other escape sequences, such as cursor movements, are removed.
+func ansiToHTML(text string) template.HTML {
+	var rendered strings.Builder
+	var style ansiStyle
+	var spanOpen bool
+
+	lastIndex := 0
+	for _, match := range ansiSequencePattern.FindAllStringSubmatchIndex(text, -1) {
+		rendered.WriteString(html.EscapeString(text[lastIndex:match[0]]))
+		lastIndex = match[1]
+
+		// only select graphic rendition (m) sequences change the style
+		if match[4] == -1 || text[match[4]:match[5]] != "m" {
+			continue
+		}
+
+		style.apply(text[match[2]:match[3]])
+
+		if spanOpen {
+			rendered.WriteString("</span>")
+			spanOpen = false
+		}
+		if css := style.css(); css != "" {
+			fmt.Fprintf(&rendered, `<span style="%s">`, css)
+			spanOpen = true
+		}
+	}
+
+	rendered.WriteString(html.EscapeString(text[lastIndex:]))
+	if spanOpen {
+		rendered.WriteString("</span>")
+	}
+
+	return template.HTML(rendered.String())
+}
+
+// ansiStyle is the text style set by ANSI select graphic rendition sequences
+type ansiStyle struct {
+	foreground string
+	background string
+	bold       bool
+	faint      bool
+	italic     bool
+	underline  bool
+}
+
+// apply updates the style with the semicolon separated SGR parameters
+func (s *ansiStyle) apply(parameters string) {
+	codes := strings.Split(parameters, ";")
+
+	for i := 0; i < len(codes); i++ {
+		code, err := strconv.Atoi(codes[i])
+		if err != nil {
+			code = 0
+		}
+
+		switch {
+		case code == 0:
+			*s = ansiStyle{}
+		case code == 1:
+			s.bold = true
+		case code == 2:
+			s.faint = true
+		case code == 3:
+			s.italic = true
+		case code == 4:
+			s.underline = true
+		case code == 22:
+			s.bold, s.faint = false, false
+		case code == 23:
+			s.italic = false
+		case code == 24:
+			s.underline = false
+		case code >= 30 && code <= 37:
+			s.foreground = ansiColours[code-30]
+		case code >= 90 && code <= 97:
+			s.foreground = ansiColours[code-90+8]
+		case code == 39:
+			s.foreground = ""
+		case code >= 40 && code <= 47:
+			s.background = ansiColours[code-40]
+		case code >= 100 && code <= 107:
+			s.background = ansiColours[code-100+8]
+		case code == 49:
+			s.background = ""
+		case code == 38 || code == 48:
+			// extended colours, i.e. 38;5;n or 38;2;r;g;b
+			colour, consumed := extendedANSIColour(codes[i+1:])
+			i += consumed
+			if code == 38 {
+				s.foreground = colour
+			} else {
+				s.background = colour
+			}
+		}
+	}
+}
+
+// css returns the inline CSS for the style
+func (s ansiStyle) css() string {
+	var declarations []string
+
+	if s.foreground != "" {
+		declarations = append(declarations, "color:"+s.foreground)
+	}
+	if s.background != "" {
+		declarations = append(declarations, "background-color:"+s.background)
+	}
+	if s.bold {
+		declarations = append(declarations, "font-weight:bold")
+	}
+	if s.faint {
+		declarations = append(declarations, "opacity:0.7")
+	}
+	if s.italic {
+		declarations = append(declarations, "font-style:italic")
+	}
+	if s.underline {
+		declarations = append(declarations, "text-decoration:underline")
+	}
+
+	return strings.Join(declarations, ";")
+}
+
+// extendedANSIColour returns the colour described by the parameters following an
+// extended colour code, and how many parameters it used
+func extendedANSIColour(parameters []string) (string, int) {
+	if len(parameters) >= 2 && parameters[0] == "5" {
+		index, err := strconv.Atoi(parameters[1])
+		if err != nil || index < 0 || index > 255 {
+			return "", 2
+		}
+
+		switch {
+		case index < 16:
+			return ansiColours[index], 2
+		case index < 232:
+			// 6x6x6 colour cube
+			index -= 16
+			levels := [6]int{0, 95, 135, 175, 215, 255}
+			return fmt.Sprintf("#%02x%02x%02x", levels[index/36], levels[(index/6)%6], levels[index%6]), 2
+		default:
+			grey := 8 + (index-232)*10
+			return fmt.Sprintf("#%02x%02x%02x", grey, grey, grey), 2
+		}
+	}
+
+	if len(parameters) >= 4 && parameters[0] == "2" {
+		var rgb [3]int
+		for i := range rgb {
+			value, err := strconv.Atoi(parameters[i+1])
+			if err != nil || value < 0 || value > 255 {
+				return "", 4
+			}
+			rgb[i] = value
+		}
+		return fmt.Sprintf("#%02x%02x%02x", rgb[0], rgb[1], rgb[2]), 4
+	}
+
+	return "", len(parameters)
+}
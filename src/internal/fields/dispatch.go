@@ -0,0 +1,201 @@
+package fields
+
+import (
+	"fmt"
+
+	"github.com/boasihq/interactive-inputs/internal/errors"
+	"github.com/boasihq/interactive-inputs/internal/toolbox"
+	"github.com/sethvargo/go-githubactions"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+
+	// DispatchInputTypes maps the types of workflow_dispatch inputs to the field types
+	// they are translated to
+	DispatchInputTypes = map[string]string{
+		"string":      "text",
+		"choice":      "select",
+		"boolean":     "boolean",
+		"number":      "number",
+		"environment": "select",
+	}
+
+	// dispatchInputProperties is a list of the properties a workflow_dispatch input supports
+	dispatchInputProperties = []string{
+		"description",
+		"required",
+		"default",
+		"type",
+		"options",
+	}
+)
+
+// translateDispatchInputs returns the fields config with any workflow_dispatch inputs translated
+// to fields. The inputs can be provided as the `inputs` of the config, in the same syntax used
+// by `on.workflow_dispatch.inputs`, or as a whole workflow whose dispatch inputs are picked out.
+// A config without dispatch inputs is returned unchanged.
+func translateDispatchInputs(fieldsString string, action *githubactions.Action) (string, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(fieldsString), &document); err != nil || len(document.Content) == 0 {
+		// syntax errors are reported when the fields are unmarshalled
+		return fieldsString, nil
+	}
+
+	root := document.Content[0]
+	inputs := toolbox.YamlMappingValue(root, "inputs")
+	isWorkflow := inputs == nil && toolbox.YamlMappingValue(root, "on") != nil && toolbox.YamlMappingValue(root, "jobs") != nil
+
+	switch {
+	case isWorkflow:
+		inputs = toolbox.YamlMappingValue(toolbox.YamlMappingValue(toolbox.YamlMappingValue(root, "on"), "workflow_dispatch"), "inputs")
+		if inputs == nil {
+			action.Errorf("No workflow_dispatch inputs found in the workflow provided as the fields config")
+			return "", errors.ErrNoFieldsProvided
+		}
+
+		// only the dispatch inputs of a workflow are relevant
+		root = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+
+	case inputs == nil:
+		return fieldsString, nil
+
+	case toolbox.YamlMappingValue(root, "fields") != nil:
+		action.Errorf("Both fields and inputs provided in the fields config - only one of them can be used")
+		return "", errors.ErrInvalidPropertyProvided
+	}
+
+	if inputs.Kind != yaml.MappingNode {
+		action.Errorf("The workflow_dispatch inputs must be a mapping of input names to their properties (line %d, column %d)", inputs.Line, inputs.Column)
+		return "", errors.ErrInvalidPropertyProvided
+	}
+
+	// every problem with the inputs is reported, so they can all be fixed at once, and the
+	// first is returned
+	var firstErr error
+	fieldNodes := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	for i := 0; i+1 < len(inputs.Content); i += 2 {
+		fieldNode, err := translateDispatchInput(inputs.Content[i], inputs.Content[i+1], action)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		fieldNodes.Content = append(fieldNodes.Content, fieldNode)
+	}
+
+	if firstErr != nil {
+		return "", firstErr
+	}
+
+	// the inputs are replaced by the fields they are translated to, keeping any steps or rules
+	translated := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "inputs" {
+			continue
+		}
+		translated.Content = append(translated.Content, root.Content[i], root.Content[i+1])
+	}
+	translated.Content = append(translated.Content, toolbox.YamlStringNode("fields"), fieldNodes)
+
+	encoded, err := yaml.Marshal(translated)
+	if err != nil {
+		action.Errorf("Unable to translate the workflow_dispatch inputs to fields: %s", err)
+		return "", errors.ErrInvalidPropertyProvided
+	}
+
+	return string(encoded), nil
+}
+
+// translateDispatchInput returns the node of the field the workflow_dispatch input is translated
+// to. Every problem with the input is reported, and the first is returned.
+func translateDispatchInput(name *yaml.Node, input *yaml.Node, action *githubactions.Action) (*yaml.Node, error) {
+	var firstErr error
+	recordErr := func(err error) {
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	properties := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	addProperty := func(key string, value *yaml.Node) {
+		properties.Content = append(properties.Content, toolbox.YamlStringNode(key), value)
+	}
+
+	// an input can be declared without any properties
+	if input.Kind == yaml.ScalarNode && input.Tag == "!!null" {
+		input = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	}
+
+	if input.Kind != yaml.MappingNode {
+		action.Errorf("The properties of workflow_dispatch input '%s' must be a mapping (line %d, column %d)", name.Value, input.Line, input.Column)
+		return nil, errors.ErrInvalidPropertyProvided
+	}
+
+	for i := 0; i+1 < len(input.Content); i += 2 {
+		key := input.Content[i]
+		if toolbox.StringInSlice(key.Value, dispatchInputProperties) {
+			continue
+		}
+
+		problem := fmt.Sprintf("Unknown property '%s' provided for workflow_dispatch input '%s' (line %d, column %d)", key.Value, name.Value, key.Line, key.Column)
+		if suggestion := closestKey(key.Value, dispatchInputProperties); suggestion != "" {
+			problem = fmt.Sprintf("%s - did you mean '%s'?", problem, suggestion)
+		}
+		action.Errorf("%s", problem)
+		recordErr(errors.ErrInvalidPropertyProvided)
+	}
+
+	inputType := "string"
+	typeNode := toolbox.YamlMappingValue(input, "type")
+	if typeNode != nil {
+		inputType = toolbox.StringStandardisedToLower(typeNode.Value)
+	}
+
+	fieldType, ok := DispatchInputTypes[inputType]
+	if !ok {
+		action.Errorf("Invalid type '%s' provided for workflow_dispatch input '%s' (line %d, column %d) - must be one of: string, choice, boolean, number, environment", inputType, name.Value, typeNode.Line, typeNode.Column)
+		return nil, errors.ErrInvalidFieldTypeProvided
+	}
+
+	options := toolbox.YamlMappingValue(input, "options")
+	if inputType == "environment" && options == nil {
+		// the environments of the repository aren't looked up, so without options the
+		// environment is entered as text
+		action.Warningf("No options provided for environment input '%s', it will be displayed as a text field", name.Value)
+		fieldType = "text"
+	}
+
+	// the dispatch form displays the description as the label of the input
+	if description := toolbox.YamlMappingValue(input, "description"); description != nil && description.Value != "" {
+		addProperty("display", description)
+	}
+
+	addProperty("type", toolbox.YamlStringNode(fieldType))
+
+	if required := toolbox.YamlMappingValue(input, "required"); required != nil {
+		addProperty("required", required)
+	}
+
+	if defaultValue := toolbox.YamlMappingValue(input, "default"); defaultValue != nil && defaultValue.Tag != "!!null" {
+		addProperty("defaultValue", toolbox.YamlStringNode(defaultValue.Value))
+	}
+
+	if fieldType == "select" {
+		if options == nil {
+			action.Errorf("No options provided for choice input '%s' (line %d, column %d)", name.Value, name.Line, name.Column)
+			return nil, errors.ErrInvalidFieldTypeProvided
+		}
+		addProperty("choices", options)
+	}
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{
+		toolbox.YamlStringNode("label"), toolbox.YamlStringNode(name.Value),
+		toolbox.YamlStringNode("properties"), properties,
+	}}, nil
+}
@@ -0,0 +1,122 @@
+package fields_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/boasihq/interactive-inputs/internal/errors"
+	"github.com/boasihq/interactive-inputs/internal/fields"
+	"github.com/sethvargo/go-githubactions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalStringIntoValidFieldsStruct_DispatchInputs(t *testing.T) {
+	tests := []struct {
+		name           string
+		fieldsString   string
+		expectedError  error
+		expectedFields []fields.Field
+		expectedSteps  int
+		expectedOutput string
+	}{
+		{
+			name:         "success - inputs of every type are translated in order",
+			fieldsString: "inputs:\n  environment:\n    description: Where to deploy\n    type: choice\n    options: [staging, production]\n    default: staging\n    required: true\n  dry-run:\n    type: boolean\n    default: false\n  version:\n    description: Version to deploy\n  region:\n    type: environment\n    options: [eu, us]\n",
+			expectedFields: []fields.Field{
+				{Label: "environment", Properties: fields.FieldProperties{
+					Display:      "Where to deploy",
+					Type:         "select",
+					Required:     true,
+					DefaultValue: "staging",
+					Choices:      []fields.Choice{{Label: "staging", Value: "staging"}, {Label: "production", Value: "production"}},
+				}},
+				{Label: "dry-run", Properties: fields.FieldProperties{Type: "boolean", DefaultValue: "false"}},
+				{Label: "version", Properties: fields.FieldProperties{Display: "Version to deploy", Type: "text"}},
+				{Label: "region", Properties: fields.FieldProperties{
+					Type:    "select",
+					Choices: []fields.Choice{{Label: "eu", Value: "eu"}, {Label: "us", Value: "us"}},
+				}},
+			},
+		},
+		{
+			name:         "success - steps are kept alongside the inputs",
+			fieldsString: "steps:\n  - label: details\ninputs:\n  version:\n",
+			expectedFields: []fields.Field{
+				{Label: "version", Properties: fields.FieldProperties{Type: "text", Step: "details"}},
+			},
+			expectedSteps: 1,
+		},
+		{
+			name:         "success - dispatch inputs are picked from a workflow",
+			fieldsString: "name: Deploy\non:\n  push:\n  workflow_dispatch:\n    inputs:\n      version:\n        description: Version to deploy\n        required: true\njobs:\n  deploy:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo ${{ inputs.version }}\n",
+			expectedFields: []fields.Field{
+				{Label: "version", Properties: fields.FieldProperties{Display: "Version to deploy", Type: "text", Required: true}},
+			},
+		},
+		{
+			name:           "success - environment without options is a text field",
+			fieldsString:   "inputs:\n  target:\n    type: environment\n",
+			expectedFields: []fields.Field{{Label: "target", Properties: fields.FieldProperties{Type: "text"}}},
+			expectedOutput: "::warning::No options provided for environment input 'target', it will be displayed as a text field\n",
+		},
+		{
+			name:           "failed - workflow without dispatch inputs",
+			fieldsString:   "on: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n",
+			expectedError:  errors.ErrNoFieldsProvided,
+			expectedOutput: "::error::No workflow_dispatch inputs found in the workflow provided as the fields config\n",
+		},
+		{
+			name:           "failed - unknown input type",
+			fieldsString:   "inputs:\n  version:\n    type: text\n",
+			expectedError:  errors.ErrInvalidFieldTypeProvided,
+			expectedOutput: "::error::Invalid type 'text' provided for workflow_dispatch input 'version' (line 3, column 11) - must be one of: string, choice, boolean, number, environment\n",
+		},
+		{
+			name:           "failed - choice without options",
+			fieldsString:   "inputs:\n  environment:\n    type: choice\n",
+			expectedError:  errors.ErrInvalidFieldTypeProvided,
+			expectedOutput: "::error::No options provided for choice input 'environment' (line 2, column 3)\n",
+		},
+		{
+			name:           "failed - misspelt input property",
+			fieldsString:   "inputs:\n  version:\n    requried: true\n",
+			expectedError:  errors.ErrInvalidPropertyProvided,
+			expectedOutput: "::error::Unknown property 'requried' provided for workflow_dispatch input 'version' (line 3, column 5) - did you mean 'required'?\n",
+		},
+		{
+			name:          "failed - problems with several inputs are reported together",
+			fieldsString:  "inputs:\n  version:\n    requried: true\n    descripton: Version\n  environment:\n    type: choice\n  replicas:\n    type: integer\n",
+			expectedError: errors.ErrInvalidPropertyProvided,
+			expectedOutput: "::error::Unknown property 'requried' provided for workflow_dispatch input 'version' (line 3, column 5) - did you mean 'required'?\n" +
+				"::error::Unknown property 'descripton' provided for workflow_dispatch input 'version' (line 4, column 5) - did you mean 'description'?\n" +
+				"::error::No options provided for choice input 'environment' (line 5, column 3)\n" +
+				"::error::Invalid type 'integer' provided for workflow_dispatch input 'replicas' (line 8, column 11) - must be one of: string, choice, boolean, number, environment\n",
+		},
+		{
+			name:           "failed - both fields and inputs",
+			fieldsString:   "fields:\n  - label: name\n    properties:\n      type: text\ninputs:\n  version:\n",
+			expectedError:  errors.ErrInvalidPropertyProvided,
+			expectedOutput: "::error::Both fields and inputs provided in the fields config - only one of them can be used\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actionLog := bytes.NewBuffer(nil)
+			action := githubactions.New(githubactions.WithWriter(actionLog))
+
+			result, err := fields.MarshalStringIntoValidFieldsStruct(tt.fieldsString, action)
+
+			assert.Equal(t, tt.expectedOutput, actionLog.String())
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedFields, result.Fields)
+			assert.Len(t, result.Steps, tt.expectedSteps)
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package fields
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/boasihq/interactive-inputs/internal/errors"
+	"github.com/boasihq/interactive-inputs/internal/toolbox"
+	"github.com/sethvargo/go-githubactions"
+)
+
+var (
+
+	// ChartTypes is a list of the ways a chart field can plot its data
+	ChartTypes = []string{
+		"line",
+		"bar",
+	}
+
+	// ChartFileExtensions is a list of the extensions of the data files a chart field can read
+	ChartFileExtensions = []string{
+		".json",
+		".csv",
+	}
+
+	// markdownFieldType displays rendered markdown
+	markdownFieldType = FieldType{
+		Name:        "markdown",
+		Properties:  []string{"content"},
+		Template:    "field-markdown",
+		DisplayOnly: true,
+		Normalise:   normaliseMarkdownField,
+		Content: func(field Field) (interface{}, error) {
+			return RenderMarkdown(field.Properties.Content)
+		},
+	}
+
+	// filePreviewFieldType displays the content of a file in the workspace
+	filePreviewFieldType = FieldType{
+		Name:        "file-preview",
+		Properties:  []string{"file"},
+		Template:    "field-file-preview",
+		DisplayOnly: true,
+		Normalise:   normaliseFilePreviewField,
+		Content:     renderFilePreview,
+	}
+
+	// diffFieldType displays a side-by-side comparison of two files in the workspace
+	diffFieldType = FieldType{
+		Name:        "diff",
+		Properties:  []string{"fromFile", "toFile"},
+		Template:    "field-diff",
+		DisplayOnly: true,
+		Normalise:   normaliseDiffField,
+		Content:     renderDiff,
+	}
+
+	// chartFieldType displays a chart of the data in a CSV or JSON file in the workspace
+	chartFieldType = FieldType{
+		Name:        "chart",
+		Properties:  []string{"file", "chartType", "xAxis", "series"},
+		Template:    "field-chart",
+		DisplayOnly: true,
+		Normalise:   normaliseChartField,
+		Content:     renderChart,
+	}
+)
+
+// IsDisplayOnly returns whether the field only displays content and doesn't capture a value
+func (field Field) IsDisplayOnly() bool {
+	return field.FieldType().DisplayOnly
+}
+
+// normaliseMarkdownField makes sure a markdown field has content to display
+func normaliseMarkdownField(field *Field, action *githubactions.Action) error {
+	if strings.TrimSpace(field.Properties.Content) == "" {
+		action.Errorf("No content provided for markdown field '%s'", field.Label)
+		return errors.ErrInvalidDisplayContentProvided
+	}
+
+	return nil
+}
+
+// normaliseFilePreviewField makes sure a file-preview field has a file to display
+func normaliseFilePreviewField(field *Field, action *githubactions.Action) error {
+	if field.Properties.File == "" {
+		action.Errorf("No file provided for file-preview field '%s'", field.Label)
+		return errors.ErrInvalidDisplayContentProvided
+	}
+
+	return resolveDisplayFiles(field, action, &field.Properties.File)
+}
+
+// normaliseDiffField makes sure a diff field has both of the files it compares
+func normaliseDiffField(field *Field, action *githubactions.Action) error {
+	if field.Properties.FromFile == "" || field.Properties.ToFile == "" {
+		action.Errorf("Both fromFile and toFile must be provided for diff field '%s'", field.Label)
+		return errors.ErrInvalidDisplayContentProvided
+	}
+
+	return resolveDisplayFiles(field, action, &field.Properties.FromFile, &field.Properties.ToFile)
+}
+
+// normaliseChartField makes sure a chart field has a data file it can read, and a
+// supported chart type, defaulting to a line chart
+func normaliseChartField(field *Field, action *githubactions.Action) error {
+	if field.Properties.File == "" {
+		action.Errorf("No file provided for chart field '%s'", field.Label)
+		return errors.ErrInvalidDisplayContentProvided
+	}
+
+	if !toolbox.StringInSlice(strings.ToLower(filepath.Ext(field.Properties.File)), ChartFileExtensions) {
+		action.Errorf("Unsupported file provided for chart field '%s' - must be one of: %s", field.Label, strings.Join(ChartFileExtensions, ", "))
+		return errors.ErrInvalidDisplayContentProvided
+	}
+
+	if field.Properties.ChartType == "" {
+		field.Properties.ChartType = "line"
+	}
+	if !toolbox.StringInSlice(field.Properties.ChartType, ChartTypes) {
+		action.Errorf("Invalid chartType provided for field '%s' - must be one of: %s", field.Label, strings.Join(ChartTypes, ", "))
+		return errors.ErrInvalidDisplayContentProvided
+	}
+
+	return resolveDisplayFiles(field, action, &field.Properties.File)
+}
+
+// resolveDisplayFiles resolves the paths of the files displayed by the field against the
+// workspace in place, making sure they exist.
+func resolveDisplayFiles(field *Field, action *githubactions.Action, filePaths ...*string) error {
+	for _, filePath := range filePaths {
+		*filePath = WorkspacePath(action, *filePath)
+
+		if _, err := os.Stat(*filePath); err != nil {
+			action.Errorf("Unable to find the file provided for field '%s': %s", field.Label, err)
+			return errors.ErrInvalidDisplayContentProvided
+		}
+	}
+
+	return nil
+}
+
+// WorkspacePath returns the given path resolved against the workspace, unless it is
+// already absolute.
+func WorkspacePath(action *githubactions.Action, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+
+	return filepath.Join(action.Getenv("GITHUB_WORKSPACE"), path)
+}
@@ -0,0 +1,200 @@
+package fields_test
+
+import (
+	"bytes"
+	"html/template"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/boasihq/interactive-inputs/internal/fields"
+	"github.com/sethvargo/go-githubactions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalStringIntoValidFieldsStruct_Display(t *testing.T) {
+	workspace := t.TempDir()
+	err := os.WriteFile(filepath.Join(workspace, "plan.txt"), []byte("+ create\n"), 0o644)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(workspace, "bench.csv"), []byte("build,p50\nv1,12\n"), 0o644)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name               string
+		fieldsString       string
+		expectedError      bool
+		expectedProperties fields.FieldProperties
+		expectedOutput     string
+	}{
+		{
+			name:               "success - markdown content",
+			fieldsString:       "fields:\n  - label: notes\n    properties:\n      type: markdown\n      content: '# Release'\n",
+			expectedError:      false,
+			expectedProperties: fields.FieldProperties{Type: "markdown", Content: "# Release"},
+		},
+		{
+			name:               "success - file-preview path is resolved against the workspace",
+			fieldsString:       "fields:\n  - label: plan\n    properties:\n      type: file-preview\n      file: plan.txt\n",
+			expectedError:      false,
+			expectedProperties: fields.FieldProperties{Type: "file-preview", File: filepath.Join(workspace, "plan.txt")},
+		},
+		{
+			name:               "success - chart defaults to a line chart",
+			fieldsString:       "fields:\n  - label: bench\n    properties:\n      type: chart\n      file: bench.csv\n",
+			expectedError:      false,
+			expectedProperties: fields.FieldProperties{Type: "chart", ChartType: "line", File: filepath.Join(workspace, "bench.csv")},
+		},
+		{
+			name:           "failed - chart with an unsupported chart type",
+			fieldsString:   "fields:\n  - label: bench\n    properties:\n      type: chart\n      chartType: pie\n      file: bench.csv\n",
+			expectedError:  true,
+			expectedOutput: "::error::Invalid chartType provided for field 'bench' - must be one of: line, bar\n",
+		},
+		{
+			name:           "failed - chart with an unsupported data file",
+			fieldsString:   "fields:\n  - label: bench\n    properties:\n      type: chart\n      file: plan.txt\n",
+			expectedError:  true,
+			expectedOutput: "::error::Unsupported file provided for chart field 'bench' - must be one of: .json, .csv\n",
+		},
+		{
+			name:           "failed - markdown without content",
+			fieldsString:   "fields:\n  - label: notes\n    properties:\n      type: markdown\n",
+			expectedError:  true,
+			expectedOutput: "::error::No content provided for markdown field 'notes'\n",
+		},
+		{
+			name:           "failed - file-preview without a file",
+			fieldsString:   "fields:\n  - label: plan\n    properties:\n      type: file-preview\n",
+			expectedError:  true,
+			expectedOutput: "::error::No file provided for file-preview field 'plan'\n",
+		},
+		{
+			name:           "failed - diff missing toFile",
+			fieldsString:   "fields:\n  - label: changes\n    properties:\n      type: diff\n      fromFile: plan.txt\n",
+			expectedError:  true,
+			expectedOutput: "::error::Both fromFile and toFile must be provided for diff field 'changes'\n",
+		},
+		{
+			name:           "failed - diff file that doesn't exist",
+			fieldsString:   "fields:\n  - label: changes\n    properties:\n      type: diff\n      fromFile: plan.txt\n      toFile: missing.txt\n",
+			expectedError:  true,
+			expectedOutput: "::error::Unable to find the file provided for field 'changes': stat " + filepath.Join(workspace, "missing.txt") + ": no such file or directory\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actionLog := bytes.NewBuffer(nil)
+			action := githubactions.New(
+				githubactions.WithWriter(actionLog),
+				githubactions.WithGetenv(func(key string) string {
+					if key == "GITHUB_WORKSPACE" {
+						return workspace
+					}
+					return ""
+				}),
+			)
+
+			result, err := fields.MarshalStringIntoValidFieldsStruct(tt.fieldsString, action)
+
+			assert.Equal(t, tt.expectedOutput, actionLog.String())
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedProperties, result.Fields[0].Properties)
+		})
+	}
+}
+
+func TestFields_DisplayFieldsAreIgnored(t *testing.T) {
+	f := &fields.Fields{
+		Fields: []fields.Field{
+			{Label: "notes", Properties: fields.FieldProperties{Type: "markdown", Content: "# Release", Required: true}},
+			{Label: "version", Properties: fields.FieldProperties{Type: "text"}},
+		},
+	}
+
+	form := url.Values{"version": {"1.2.0"}}
+
+	assert.Equal(t, fields.FieldErrors{}, f.Validate(form, "", fields.TemplateData{}))
+
+	outputs, err := f.Outputs(form)
+	assert.NoError(t, err)
+	assert.Equal(t, []fields.Output{{Label: "version", Value: "1.2.0"}}, outputs)
+}
+
+func TestField_Content(t *testing.T) {
+	workspace := t.TempDir()
+	planFile := filepath.Join(workspace, "plan.txt")
+	err := os.WriteFile(planFile, []byte("\x1b[32m+ create\x1b[0m <db>\n"), 0o644)
+	assert.NoError(t, err)
+	appliedFile := filepath.Join(workspace, "applied.txt")
+	err = os.WriteFile(appliedFile, []byte("+ update <db>\n"), 0o644)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name            string
+		field           fields.Field
+		expectedContent interface{}
+		expectedOk      bool
+		expectedError   bool
+	}{
+		{
+			name:            "markdown - rendered as sanitised html",
+			field:           fields.Field{Label: "notes", Properties: fields.FieldProperties{Type: "markdown", Content: "**Release** <script>alert(1)</script>"}},
+			expectedContent: template.HTML("<p><strong>Release</strong> alert(1)</p>\n"),
+			expectedOk:      true,
+		},
+		{
+			name:  "file-preview - ansi colours converted and the rest escaped",
+			field: fields.Field{Label: "plan", Properties: fields.FieldProperties{Type: "file-preview", File: planFile}},
+			expectedContent: &fields.FileContent{
+				Name:    planFile,
+				Content: template.HTML("<span style=\"color:#16a34a\">+ create</span> &lt;db&gt;\n"),
+			},
+			expectedOk: true,
+		},
+		{
+			name:  "diff - changed lines paired up",
+			field: fields.Field{Label: "changes", Properties: fields.FieldProperties{Type: "diff", FromFile: planFile, ToFile: appliedFile}},
+			expectedContent: &fields.DiffContent{
+				FromName: planFile,
+				ToName:   appliedFile,
+				Rows: []fields.DiffRow{
+					{Kind: "replace", FromNumber: 1, FromLine: "+ create <db>", ToNumber: 1, ToLine: "+ update <db>"},
+				},
+			},
+			expectedOk: true,
+		},
+		{
+			name:          "file-preview - missing file",
+			field:         fields.Field{Label: "plan", Properties: fields.FieldProperties{Type: "file-preview", File: filepath.Join(workspace, "missing.txt")}},
+			expectedOk:    true,
+			expectedError: true,
+		},
+		{
+			name:  "text - no content prepared",
+			field: fields.Field{Label: "version", Properties: fields.FieldProperties{Type: "text"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, ok, err := tt.field.Content()
+
+			assert.Equal(t, tt.expectedOk, ok)
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedContent, content)
+		})
+	}
+}
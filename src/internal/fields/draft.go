@@ -0,0 +1,31 @@
+package fields
+
+import (
+	"net/url"
+	"strings"
+)
+
+// DraftValues returns the submitted form values that can be saved in a draft, which are
+// those of the inputs of fields with an output. Uploaded files are already held in the
+// runner's cache, and confirm fields must be confirmed again once the portal is reloaded,
+// so their values, along with those of secret fields, display-only fields and unknown
+// inputs, are left out.
+func (f *Fields) DraftValues(form url.Values) url.Values {
+	var values url.Values = make(url.Values)
+
+	for _, field := range f.Fields {
+		fieldType := field.FieldType()
+		if fieldType.DisplayOnly || fieldType.Output == nil || field.Properties.Secret {
+			continue
+		}
+
+		for inputName, inputValues := range form {
+			if inputName == field.Label ||
+				(fieldType.Name == groupFieldType.Name && strings.HasPrefix(inputName, field.Label+groupInputNameSeparator)) {
+				values[inputName] = inputValues
+			}
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,48 @@
+package fields_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/boasihq/interactive-inputs/internal/fields"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFields_DraftValues(t *testing.T) {
+	f := &fields.Fields{
+		Fields: []fields.Field{
+			{Label: "name", Properties: fields.FieldProperties{Type: "text"}},
+			{Label: "token", Properties: fields.FieldProperties{Type: "text", Secret: true}},
+			{Label: "regions", Properties: fields.FieldProperties{Type: "multiselect", Choices: []fields.Choice{
+				{Label: "eu-west-1", Value: "eu-west-1"},
+				{Label: "us-east-1", Value: "us-east-1"},
+			}}},
+			{Label: "services", Properties: fields.FieldProperties{Type: "group", Fields: []fields.Field{
+				{Label: "name", Properties: fields.FieldProperties{Type: "text"}},
+			}}},
+			{Label: "upload", Properties: fields.FieldProperties{Type: "file"}},
+			{Label: "confirmation", Properties: fields.FieldProperties{Type: "confirm", Phrase: "delete"}},
+			{Label: "notes", Properties: fields.FieldProperties{Type: "markdown", Content: "Notes"}},
+		},
+	}
+
+	form := url.Values{
+		"name":            {"alice"},
+		"token":           {"s3cr3t"},
+		"regions":         {"eu-west-1", "us-east-1"},
+		"services.0.name": {"api"},
+		"services.3.name": {"web"},
+		"servicesname":    {"ignored"},
+		"upload":          {"plan.txt"},
+		"confirmation":    {"delete"},
+		"notes":           {"ignored"},
+		"decision":        {"approved"},
+	}
+
+	assert.Equal(t, url.Values{
+		"name":            {"alice"},
+		"regions":         {"eu-west-1", "us-east-1"},
+		"services.0.name": {"api"},
+		"services.3.name": {"web"},
+	}, f.DraftValues(form))
+}
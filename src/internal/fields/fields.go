@@ -5,30 +5,23 @@ import (
 
 	"github.com/boasihq/interactive-inputs/internal/errors"
 	"github.com/boasihq/interactive-inputs/internal/toolbox"
+	"github.com/santhosh-tekuri/jsonschema/v6"
 	"github.com/sethvargo/go-githubactions"
 	"gopkg.in/yaml.v2"
 )
 
-var (
-
-	// ValidFieldTypes  is a list of valid field types supported by the action.
-	ValidFieldTypes = []string{
-		"text",
-		"textarea",
-		"number",
-		"boolean",
-		"select",
-		"multiselect",
-		"file",
-		"multifile",
-	}
-)
-
 // Fields is a struct that contains a list of Field structs, which represent the fields in a form to display to users.
 // The Fields struct is typically used to define the structure and properties of the fields that will be displayed to users.
 // Each Field in the Fields slice has a Label and a list of FieldProperties that define the display, type, and other characteristics of the field.
+//
+// Steps optionally splits the fields across an ordered set of pages, which the user
+// moves through one at a time.
+//
+// Rules optionally constrain the values of multiple fields in relation to each other.
 type Fields struct {
 	Fields []Field `yaml:"fields"`
+	Steps  []Step  `yaml:"steps"`
+	Rules  []Rule  `yaml:"rules"`
 }
 
 // Field represents a field in the Fields struct. It contains a label and a list of field properties.
@@ -41,7 +34,7 @@ type Field struct {
 // Display is the label to show the user for the field.
 // Type is the type of the field, such as "text" or "options".
 // Description is a description of the field to show the user.
-// Choices is a list of options to display for the field if the Type is "options".
+// Choices is a list of options to display for the field if the Type is "select" or "multiselect".
 // Required indicates whether the field must be filled out.
 // MaxLength is the maximum length of the field's value.
 // DisableAutoCopySelection is whether the field should stop automatically coping the selected option to the clipboard (valid fields: select, multiselect).
@@ -49,12 +42,12 @@ type FieldProperties struct {
     Display                  string   `yaml:"display"`
     Type                     string   `yaml:"type"`
     Description              string   `yaml:"description"`
-    Choices                  []string `yaml:"choices"`
+    Choices                  []Choice `yaml:"choices"`
     Required                 bool     `yaml:"required"`
     MaxLength                int      `yaml:"maxLength"`
     Placeholder              string   `yaml:"placeholder"`
-    NumberMin                int      `yaml:"minNumber"`
-    NumberMax                int      `yaml:"maxNumber"`
+    NumberMin                *float64 `yaml:"minNumber"`
+    NumberMax                *float64 `yaml:"maxNumber"`
     DefaultValue             string   `yaml:"defaultValue"`
     ReadOnly                 bool     `yaml:"readOnly"`
     DisableAutoCopySelection bool     `yaml:"disableAutoCopySelection"`
@@ -74,6 +67,187 @@ type FieldProperties struct {
     // OutputTitle is an optional title shown in the output window. If empty,
     // a sensible default such as "Previous Output" is used.
     OutputTitle              string   `yaml:"outputTitle"`
+
+    // Section is the name of the section the field is displayed in. Consecutive
+    // fields sharing a section are grouped together under the section's name.
+    Section                  string   `yaml:"section"`
+
+    // Step is the label of the step the field is displayed on when the form is
+    // split into multiple steps.
+    Step                     string   `yaml:"step"`
+
+    // Fields are the sub-fields making up each item of a group field
+    Fields                   []Field  `yaml:"fields"`
+
+    // MinItems is the minimum number of items that must be provided for a group field
+    MinItems                 int      `yaml:"minItems"`
+
+    // MaxItems is the maximum number of items that can be provided for a group field.
+    // If zero, there is no limit.
+    MaxItems                 int      `yaml:"maxItems"`
+
+    // Schema is an inline JSON Schema, written in JSON or YAML, that the value of a
+    // json or yaml field must match
+    Schema                   string   `yaml:"schema"`
+
+    // SchemaFile is the path, relative to the workspace, of a JSON Schema file that
+    // the value of a json or yaml field must match
+    SchemaFile               string   `yaml:"schemaFile"`
+
+    // NumberStep is the increment a number field's value must be a multiple of,
+    // counted from minNumber when provided
+    NumberStep               *float64 `yaml:"numberStep"`
+
+    // Precision is the maximum number of decimal places allowed for a number field.
+    // The output is formatted with exactly this number of decimal places.
+    Precision                *int     `yaml:"precision"`
+
+    // Integer restricts a number field to whole numbers
+    Integer                  bool     `yaml:"integer"`
+
+    // Phrase is the text that must be typed exactly to submit a confirm field. It can
+    // reference the answers of other fields and the GitHub context.
+    Phrase                   string   `yaml:"phrase"`
+
+    // Content is the markdown displayed by a markdown field
+    Content                  string   `yaml:"content"`
+
+    // File is the path, relative to the workspace, of the file displayed by a
+    // file-preview field
+    File                     string   `yaml:"file"`
+
+    // FromFile and ToFile are the paths, relative to the workspace, of the files
+    // compared side-by-side by a diff field
+    FromFile                 string   `yaml:"fromFile"`
+    ToFile                   string   `yaml:"toFile"`
+
+    // ChartType is how a chart field plots its data, either "line" (default) or "bar"
+    ChartType                string   `yaml:"chartType"`
+
+    // XAxis is the column of a chart field's data used for the x-axis categories.
+    // If empty, the first column is used.
+    XAxis                    string   `yaml:"xAxis"`
+
+    // Series are the columns of a chart field's data that are plotted. If empty,
+    // every column other than the x-axis is plotted.
+    Series                   []string `yaml:"series"`
+
+    // Multiple allows more than one region to be selected for a region field
+    Multiple                 bool     `yaml:"multiple"`
+
+    // Map is the name of the jsvectormap map displayed by a region field. If empty,
+    // "world" is used.
+    Map                      string   `yaml:"map"`
+
+    // MapFile is the path, relative to the workspace, of the jsvectormap map definition
+    // displayed by a region field. If empty, the regions are listed without a map.
+    MapFile                  string   `yaml:"mapFile"`
+
+    // EditableBy are the teams, given as org/team-slug, whose members can change the
+    // field's value. Anyone else is given its default value. If empty, anyone can.
+    EditableBy               []string `yaml:"editableBy"`
+
+    // Secret hides the value of a text field as it is entered. The value is masked in
+    // the logs and when reviewed, and isn't saved in drafts.
+    Secret                   bool     `yaml:"secret"`
+
+    // schema is the compiled schema of a json or yaml field
+    schema                   *jsonschema.Schema
+
+    // locked is true when the user viewing the portal can't change the field's value
+    locked                   bool
+}
+
+// Choice represents a single option of a select or multiselect field. A choice can be
+// provided either as a plain string, in which case the string is used as both the label
+// and the value, or as an object that separates what the user sees (Label) from what is
+// emitted as the output (Value).
+// Description is an optional hint displayed alongside the label.
+// Group is the name of the option group (optgroup) the choice should be displayed under.
+// Disabled indicates whether the choice is shown but cannot be selected.
+// Region is the code of the map region that selects the choice of a region field.
+// SelectableBy are the teams, given as org/team-slug, whose members can select the choice.
+// If empty, anyone can.
+type Choice struct {
+	Label        string   `yaml:"label"`
+	Value        string   `yaml:"value"`
+	Description  string   `yaml:"description"`
+	Group        string   `yaml:"group"`
+	Disabled     bool     `yaml:"disabled"`
+	Region       string   `yaml:"region"`
+	SelectableBy []string `yaml:"selectableBy"`
+}
+
+// ChoiceGroup is a named collection of choices, used to render option groups.
+// Choices without a group are collected in a ChoiceGroup with an empty Name.
+type ChoiceGroup struct {
+	Name    string
+	Choices []Choice
+}
+
+// UnmarshalYAML allows a choice to be defined as either a plain string or an object,
+// keeping plain string lists backwards compatible.
+func (c *Choice) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var plainChoice string
+	if err := unmarshal(&plainChoice); err == nil {
+		*c = Choice{Label: plainChoice, Value: plainChoice}
+		return nil
+	}
+
+	// alias the type to avoid recursively calling this method
+	type choiceObject Choice
+	var choice choiceObject
+	if err := unmarshal(&choice); err != nil {
+		return err
+	}
+
+	*c = Choice(choice)
+
+	// fall back to the value as the label (and vice versa) when only
+	// one of them has been provided
+	if c.Label == "" {
+		c.Label = c.Value
+	}
+	if c.Value == "" {
+		c.Value = c.Label
+	}
+
+	return nil
+}
+
+// ChoiceGroups returns the field's choices grouped by their Group, in the order
+// each group first appears. Choices without a group are returned in a group with
+// an empty Name so they can be rendered without an optgroup.
+func (p FieldProperties) ChoiceGroups() []ChoiceGroup {
+	var groups []ChoiceGroup
+	var groupIndex map[string]int = make(map[string]int)
+
+	for _, choice := range p.Choices {
+		i, ok := groupIndex[choice.Group]
+		if !ok {
+			groups = append(groups, ChoiceGroup{Name: choice.Group})
+			i = len(groups) - 1
+			groupIndex[choice.Group] = i
+		}
+
+		groups[i].Choices = append(groups[i].Choices, choice)
+	}
+
+	return groups
+}
+
+// ChoiceValues returns the values of the field's choices that can be selected by the user
+func (p FieldProperties) ChoiceValues() []string {
+	var values []string = make([]string, 0, len(p.Choices))
+
+	for _, choice := range p.Choices {
+		if choice.Disabled {
+			continue
+		}
+		values = append(values, choice.Value)
+	}
+
+	return values
 }
 
 // MarshalStringIntoValidFieldsStruct takes a YAML-formatted string representation of a Fields
@@ -86,7 +260,12 @@ func MarshalStringIntoValidFieldsStruct(fieldsString string, action *githubactio
 	var detectedFieldLabels []string = make([]string, 0)
 	fields.Fields = make([]Field, 0)
 
-	err := yaml.Unmarshal([]byte(fieldsString), &fields)
+	fieldsString, err := translateDispatchInputs(fieldsString, action)
+	if err != nil {
+		return nil, err
+	}
+
+	err = yaml.Unmarshal([]byte(fieldsString), &fields)
 	if err != nil {
 
 		action.Errorf("Unmarshalling field(s): %s", err)
@@ -98,43 +277,124 @@ func MarshalStringIntoValidFieldsStruct(fieldsString string, action *githubactio
 		return nil, errors.ErrNoFieldsProvided
 	}
 
-	for i, field := range fields.Fields {
-		if !toolbox.StringInSlice(
-			toolbox.StringStandardisedToLower(field.Properties.Type),
-			ValidFieldTypes,
-		) {
-			action.Errorf(
-				"Invalid field type '%s' provided for field '%s'. Valid field types are: %s",
-				field.Properties.Type,
-				field.Label,
-				strings.Join(ValidFieldTypes, ", "),
-			)
-
-			return nil, errors.ErrInvalidFieldTypeProvided
+	// every problem with the config is reported, so they can all be fixed at once, and
+	// the first is returned
+	var firstErr error
+	recordErr := func(err error) {
+		if firstErr == nil {
+			firstErr = err
 		}
+	}
 
-		// make sure label is camel case
-		labelKebabCase, err := toolbox.StringConvertToKebabCase(
-			toolbox.StringRemoveSpecialCharactersWith(field.Label, ""),
-		)
-		if err != nil || labelKebabCase == "" {
-			action.Errorf("Invalid label provided - '%s' is not kebab case compatible", field.Label)
-			return nil, errors.ErrInvalidLabelProvided
-		}
-		fields.Fields[i].Label = labelKebabCase
+	for _, problem := range checkStrictly(fieldsString) {
+		action.Errorf("%s", problem)
+		recordErr(errors.ErrInvalidPropertyProvided)
+	}
 
-		// make sure the type is lower case
-		fields.Fields[i].Properties.Type = toolbox.StringStandardisedToLower(field.Properties.Type)
+	for i, field := range fields.Fields {
+		err = normaliseField(&fields.Fields[i], FieldTypeNames(nil), action)
+		if err != nil {
+			recordErr(err)
+			continue
+		}
 
 		// check if the field label has already been detected
 		if toolbox.StringInSlice(field.Label, detectedFieldLabels) {
 			action.Errorf("Duplicate field label detected: '%s'", field.Label)
-			return nil, errors.ErrDuplicateFieldLabelDetected
+			recordErr(errors.ErrDuplicateFieldLabelDetected)
+			continue
 		}
 
 		// add the field label to the detected field labels
 		detectedFieldLabels = append(detectedFieldLabels, field.Label)
 	}
 
+	err = resolveSteps(&fields, action)
+	if err != nil {
+		recordErr(err)
+	}
+
+	err = resolveRules(&fields, action)
+	if err != nil {
+		recordErr(err)
+	}
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
 	return &fields, nil
 }
+
+// normaliseField validates the given field's type and properties against the provided list
+// of valid field types, standardising its label and type in place.
+func normaliseField(field *Field, validFieldTypes []string, action *githubactions.Action) error {
+	if !toolbox.StringInSlice(
+		toolbox.StringStandardisedToLower(field.Properties.Type),
+		validFieldTypes,
+	) {
+		action.Errorf(
+			"Invalid field type '%s' provided for field '%s'. Valid field types are: %s",
+			field.Properties.Type,
+			field.Label,
+			strings.Join(validFieldTypes, ", "),
+		)
+
+		return errors.ErrInvalidFieldTypeProvided
+	}
+
+	// make sure label is camel case
+	labelKebabCase, err := toolbox.StringConvertToKebabCase(
+		toolbox.StringRemoveSpecialCharactersWith(field.Label, ""),
+	)
+	if err != nil || labelKebabCase == "" {
+		action.Errorf("Invalid label provided - '%s' is not kebab case compatible", field.Label)
+		return errors.ErrInvalidLabelProvided
+	}
+	field.Label = labelKebabCase
+
+	// make sure the type is lower case
+	field.Properties.Type = toolbox.StringStandardisedToLower(field.Properties.Type)
+
+	// make sure every choice has a value and values are unique
+	var detectedChoiceValues []string = make([]string, 0, len(field.Properties.Choices))
+	for _, choice := range field.Properties.Choices {
+		if choice.Value == "" {
+			action.Errorf("Invalid choice provided for field '%s' - a choice must have a label or value", field.Label)
+			return errors.ErrInvalidChoiceProvided
+		}
+
+		if toolbox.StringInSlice(choice.Value, detectedChoiceValues) {
+			action.Errorf("Duplicate choice value detected for field '%s': '%s'", field.Label, choice.Value)
+			return errors.ErrDuplicateChoiceValueDetected
+		}
+
+		detectedChoiceValues = append(detectedChoiceValues, choice.Value)
+	}
+
+	// make sure templated default values, placeholders and descriptions can be expanded
+	if err := ValidateTemplate(field.Properties.DefaultValue); err != nil {
+		action.Errorf("Invalid default value template provided for field '%s': %s", field.Label, err)
+		return errors.ErrInvalidTemplateProvided
+	}
+
+	if err := ValidateTemplate(field.Properties.Placeholder); err != nil {
+		action.Errorf("Invalid placeholder template provided for field '%s': %s", field.Label, err)
+		return errors.ErrInvalidTemplateProvided
+	}
+
+	if err := ValidateTemplate(field.Properties.Description); err != nil {
+		action.Errorf("Invalid description template provided for field '%s': %s", field.Label, err)
+		return errors.ErrInvalidTemplateProvided
+	}
+
+	if err := normalisePermissions(field, action); err != nil {
+		return err
+	}
+
+	if normalise := field.FieldType().Normalise; normalise != nil {
+		return normalise(field, action)
+	}
+
+	return nil
+}
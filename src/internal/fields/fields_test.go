@@ -80,6 +80,39 @@ func TestMarshalStringIntoValidFieldsStruct(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:          "success - plain and object choices parsed",
+			fieldsString:  "fields:\n  - label: environment\n    properties:\n      display: Environment\n      type: select\n      choices:\n        - staging\n        - label: Production (eu-west-1)\n          value: prod-euw1\n          description: Primary region\n          group: Production\n        - value: prod-use1\n          group: Production\n          disabled: true\n",
+			expectedError: false,
+			expectedField: &fields.Fields{
+				Fields: []fields.Field{
+					{
+						Label: "environment",
+						Properties: fields.FieldProperties{
+							Display: "Environment",
+							Type:    "select",
+							Choices: []fields.Choice{
+								{Label: "staging", Value: "staging"},
+								{Label: "Production (eu-west-1)", Value: "prod-euw1", Description: "Primary region", Group: "Production"},
+								{Label: "prod-use1", Value: "prod-use1", Group: "Production", Disabled: true},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:           "failed - duplicate choice values",
+			fieldsString:   "fields:\n  - label: environment\n    properties:\n      type: select\n      choices:\n        - staging\n        - label: Staging\n          value: staging\n",
+			expectedError:  true,
+			expectedOutput: "::error::Duplicate choice value detected for field 'environment': 'staging'\n",
+		},
+		{
+			name:           "failed - choice without label or value",
+			fieldsString:   "fields:\n  - label: environment\n    properties:\n      type: select\n      choices:\n        - description: Nothing to select\n",
+			expectedError:  true,
+			expectedOutput: "::error::Invalid choice provided for field 'environment' - a choice must have a label or value\n",
+		},
 		{
 			name:          "Empty string",
 			fieldsString:  "",
@@ -129,3 +162,31 @@ func TestMarshalStringIntoValidFieldsStruct(t *testing.T) {
 		})
 	}
 }
+
+func TestFieldProperties_ChoiceGroups(t *testing.T) {
+	properties := fields.FieldProperties{
+		Choices: []fields.Choice{
+			{Label: "dev", Value: "dev"},
+			{Label: "Production (eu-west-1)", Value: "prod-euw1", Group: "Production"},
+			{Label: "Staging", Value: "stg", Group: "Pre-production"},
+			{Label: "Production (us-east-1)", Value: "prod-use1", Group: "Production", Disabled: true},
+			{Label: "local", Value: "local"},
+		},
+	}
+
+	assert.Equal(t, []fields.ChoiceGroup{
+		{Name: "", Choices: []fields.Choice{
+			{Label: "dev", Value: "dev"},
+			{Label: "local", Value: "local"},
+		}},
+		{Name: "Production", Choices: []fields.Choice{
+			{Label: "Production (eu-west-1)", Value: "prod-euw1", Group: "Production"},
+			{Label: "Production (us-east-1)", Value: "prod-use1", Group: "Production", Disabled: true},
+		}},
+		{Name: "Pre-production", Choices: []fields.Choice{
+			{Label: "Staging", Value: "stg", Group: "Pre-production"},
+		}},
+	}, properties.ChoiceGroups())
+
+	assert.Equal(t, []string{"dev", "prod-euw1", "stg", "local"}, properties.ChoiceValues())
+}
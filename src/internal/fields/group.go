@@ -0,0 +1,188 @@
+package fields
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/boasihq/interactive-inputs/internal/errors"
+	"github.com/boasihq/interactive-inputs/internal/toolbox"
+	"github.com/sethvargo/go-githubactions"
+)
+
+// groupFieldType is a repeatable set of sub-fields, output as a JSON array of objects
+var groupFieldType = FieldType{
+	Name:       "group",
+	Properties: []string{"fields", "minItems", "maxItems"},
+	Template:   "field-group",
+	Normalise:  normaliseGroupField,
+	Validate: func(field Field, form url.Values, _ TemplateData) FieldErrors {
+		return field.validateGroup(form)
+	},
+	Output: func(field Field, form url.Values) (string, bool, error) {
+		value, err := field.groupOutput(form)
+		return value, err == nil, err
+	},
+	TypedValue: func(field Field, form url.Values) interface{} {
+		var items []interface{}
+		if output, err := field.groupOutput(form); err == nil {
+			_ = json.Unmarshal([]byte(output), &items)
+		}
+		return items
+	},
+}
+
+// groupInputNameSeparator separates the group label, item index and sub-field label
+// in the names of a group's inputs, i.e. services.0.version
+const groupInputNameSeparator string = "."
+
+// GroupInputName returns the name of the input holding the value of the given
+// sub-field for the item at the given index of a group field.
+func GroupInputName(groupLabel string, index int, subFieldLabel string) string {
+	return strings.Join([]string{groupLabel, strconv.Itoa(index), subFieldLabel}, groupInputNameSeparator)
+}
+
+// normaliseGroupField validates the sub-fields and item limits of a group field
+func normaliseGroupField(field *Field, action *githubactions.Action) error {
+	var detectedSubFieldLabels []string = make([]string, 0, len(field.Properties.Fields))
+
+	if len(field.Properties.Fields) == 0 {
+		action.Errorf("No sub-fields provided for group field '%s'", field.Label)
+		return errors.ErrNoFieldsProvided
+	}
+
+	if field.Properties.MinItems < 0 || field.Properties.MaxItems < 0 ||
+		(field.Properties.MaxItems > 0 && field.Properties.MinItems > field.Properties.MaxItems) {
+		action.Errorf("Invalid item limits provided for group field '%s' - minItems (%d) must not be greater than maxItems (%d)", field.Label, field.Properties.MinItems, field.Properties.MaxItems)
+		return errors.ErrInvalidGroupItemLimitsProvided
+	}
+
+	for i := range field.Properties.Fields {
+		err := normaliseField(&field.Properties.Fields[i], FieldTypeNames(func(fieldType FieldType) bool {
+			return fieldType.Groupable
+		}), action)
+		if err != nil {
+			return err
+		}
+
+		subFieldLabel := field.Properties.Fields[i].Label
+		if field.Properties.Fields[i].hasPermissions() {
+			action.Errorf("Permissions can't be provided for sub-field '%s' of group field '%s' - only the fields of the form can be limited to teams", subFieldLabel, field.Label)
+			return errors.ErrInvalidPermissionProvided
+		}
+
+		if field.Properties.Fields[i].Properties.Secret {
+			action.Errorf("Sub-field '%s' of group field '%s' can't be secret - only the fields of the form can be kept secret", subFieldLabel, field.Label)
+			return errors.ErrInvalidSecretProvided
+		}
+
+		if toolbox.StringInSlice(subFieldLabel, detectedSubFieldLabels) {
+			action.Errorf("Duplicate field label detected in group field '%s': '%s'", field.Label, subFieldLabel)
+			return errors.ErrDuplicateFieldLabelDetected
+		}
+
+		detectedSubFieldLabels = append(detectedSubFieldLabels, subFieldLabel)
+	}
+
+	return nil
+}
+
+// MinimumItems returns the minimum number of items required for the group field,
+// which is at least one when the field is required.
+func (field Field) MinimumItems() int {
+	if field.Properties.Required && field.Properties.MinItems < 1 {
+		return 1
+	}
+
+	return field.Properties.MinItems
+}
+
+// groupItemIndexes returns the sorted indexes of the items submitted for the group field.
+// Items can be removed in the portal, so the indexes are not necessarily contiguous.
+func (field Field) groupItemIndexes(form url.Values) []int {
+	var indexes []int
+	var detectedIndexes map[int]bool = make(map[int]bool)
+
+	for key := range form {
+		remainder, ok := strings.CutPrefix(key, field.Label+groupInputNameSeparator)
+		if !ok {
+			continue
+		}
+
+		rawIndex, _, ok := strings.Cut(remainder, groupInputNameSeparator)
+		if !ok {
+			continue
+		}
+
+		index, err := strconv.Atoi(rawIndex)
+		if err != nil || detectedIndexes[index] {
+			continue
+		}
+
+		detectedIndexes[index] = true
+		indexes = append(indexes, index)
+	}
+
+	sort.Ints(indexes)
+
+	return indexes
+}
+
+// validateGroup checks the items submitted for the group field, returning any
+// problems with the number of items under the group's label and any problems
+// with the items' values under the name of the offending input. Read-only groups can't
+// be changed, so they aren't validated.
+func (field Field) validateGroup(form url.Values) FieldErrors {
+	var fieldErrors FieldErrors = make(FieldErrors)
+	if field.Properties.ReadOnly {
+		return fieldErrors
+	}
+	var indexes []int = field.groupItemIndexes(form)
+
+	if len(indexes) < field.MinimumItems() {
+		fieldErrors[field.Label] = fmt.Sprintf("At least %d item(s) must be provided", field.MinimumItems())
+	}
+
+	if field.Properties.MaxItems > 0 && len(indexes) > field.Properties.MaxItems {
+		fieldErrors[field.Label] = fmt.Sprintf("At most %d item(s) can be provided", field.Properties.MaxItems)
+	}
+
+	for _, index := range indexes {
+		for _, subField := range field.Properties.Fields {
+			inputName := GroupInputName(field.Label, index, subField.Label)
+			if message := subField.validateValues(form[inputName]); message != "" {
+				fieldErrors[inputName] = message
+			}
+		}
+	}
+
+	return fieldErrors
+}
+
+// groupOutput returns the items submitted for the group field as a JSON array of
+// objects keyed by sub-field label.
+func (field Field) groupOutput(form url.Values) (string, error) {
+	var items []map[string]interface{} = make([]map[string]interface{}, 0)
+
+	for _, index := range field.groupItemIndexes(form) {
+		item := make(map[string]interface{}, len(field.Properties.Fields))
+
+		for _, subField := range field.Properties.Fields {
+			item[subField.Label] = subField.TypedValue(url.Values{
+				subField.Label: form[GroupInputName(field.Label, index, subField.Label)],
+			})
+		}
+
+		items = append(items, item)
+	}
+
+	output, err := json.Marshal(items)
+	if err != nil {
+		return "", err
+	}
+
+	return string(output), nil
+}
@@ -0,0 +1,155 @@
+package fields_test
+
+import (
+	"bytes"
+	"net/url"
+	"testing"
+
+	"github.com/boasihq/interactive-inputs/internal/fields"
+	"github.com/sethvargo/go-githubactions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalStringIntoValidFieldsStruct_Group(t *testing.T) {
+	tests := []struct {
+		name              string
+		fieldsString      string
+		expectedError     bool
+		expectedSubFields []string
+		expectedOutput    string
+	}{
+		{
+			name:              "success - group with sub-fields",
+			fieldsString:      "fields:\n  - label: services\n    properties:\n      type: Group\n      maxItems: 3\n      fields:\n        - label: Service Name\n          properties:\n            type: text\n        - label: version\n          properties:\n            type: select\n            choices: ['1.0', '2.0']\n",
+			expectedError:     false,
+			expectedSubFields: []string{"service-name", "version"},
+		},
+		{
+			name:           "failed - group without sub-fields",
+			fieldsString:   "fields:\n  - label: services\n    properties:\n      type: group\n",
+			expectedError:  true,
+			expectedOutput: "::error::No sub-fields provided for group field 'services'\n",
+		},
+		{
+			name:           "failed - unsupported sub-field type",
+			fieldsString:   "fields:\n  - label: services\n    properties:\n      type: group\n      fields:\n        - label: artifact\n          properties:\n            type: file\n",
+			expectedError:  true,
+			expectedOutput: "::error::Invalid field type 'file' provided for field 'artifact'. Valid field types are: text, textarea, number, boolean, select, multiselect\n",
+		},
+		{
+			name:           "failed - min items greater than max items",
+			fieldsString:   "fields:\n  - label: services\n    properties:\n      type: group\n      minItems: 3\n      maxItems: 2\n      fields:\n        - label: name\n          properties:\n            type: text\n",
+			expectedError:  true,
+			expectedOutput: "::error::Invalid item limits provided for group field 'services' - minItems (3) must not be greater than maxItems (2)\n",
+		},
+		{
+			name:           "failed - duplicate sub-field labels",
+			fieldsString:   "fields:\n  - label: services\n    properties:\n      type: group\n      fields:\n        - label: name\n          properties:\n            type: text\n        - label: Name\n          properties:\n            type: textarea\n",
+			expectedError:  true,
+			expectedOutput: "::error::Duplicate field label detected in group field 'services': 'name'\n",
+		},
+		{
+			name:           "failed - secret sub-field",
+			fieldsString:   "fields:\n  - label: services\n    properties:\n      type: group\n      fields:\n        - label: token\n          properties:\n            type: text\n            secret: true\n",
+			expectedError:  true,
+			expectedOutput: "::error::Sub-field 'token' of group field 'services' can't be secret - only the fields of the form can be kept secret\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actionLog := bytes.NewBuffer(nil)
+			action := githubactions.New(githubactions.WithWriter(actionLog))
+
+			result, err := fields.MarshalStringIntoValidFieldsStruct(tt.fieldsString, action)
+
+			assert.Equal(t, tt.expectedOutput, actionLog.String())
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "group", result.Fields[0].Properties.Type)
+
+			var subFieldLabels []string
+			for _, subField := range result.Fields[0].Properties.Fields {
+				subFieldLabels = append(subFieldLabels, subField.Label)
+			}
+			assert.Equal(t, tt.expectedSubFields, subFieldLabels)
+		})
+	}
+}
+
+func TestFields_ValidateGroup(t *testing.T) {
+	f := &fields.Fields{
+		Fields: []fields.Field{
+			{Label: "services", Properties: fields.FieldProperties{Type: "group", Required: true, MaxItems: 2, Fields: []fields.Field{
+				{Label: "name", Properties: fields.FieldProperties{Type: "text", Required: true}},
+				{Label: "replicas", Properties: fields.FieldProperties{Type: "number", NumberMax: numberPtr(5)}},
+			}}},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		form           url.Values
+		expectedErrors fields.FieldErrors
+	}{
+		{
+			name:           "valid items with non contiguous indexes",
+			form:           url.Values{"services.0.name": {"api"}, "services.3.name": {"worker"}, "services.3.replicas": {"2"}},
+			expectedErrors: fields.FieldErrors{},
+		},
+		{
+			name:           "required group without items",
+			form:           url.Values{},
+			expectedErrors: fields.FieldErrors{"services": "At least 1 item(s) must be provided"},
+		},
+		{
+			name: "too many items and invalid item values",
+			form: url.Values{"services.0.name": {""}, "services.1.name": {"api"}, "services.2.name": {"worker"}, "services.2.replicas": {"9"}},
+			expectedErrors: fields.FieldErrors{
+				"services":            "At most 2 item(s) can be provided",
+				"services.0.name":     "This field is required",
+				"services.2.replicas": "Must be at most 5",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedErrors, f.Validate(tt.form, "", fields.TemplateData{}))
+		})
+	}
+}
+
+func TestFields_OutputsGroup(t *testing.T) {
+	f := &fields.Fields{
+		Fields: []fields.Field{
+			{Label: "environment", Properties: fields.FieldProperties{Type: "text"}},
+			{Label: "services", Properties: fields.FieldProperties{Type: "group", Fields: []fields.Field{
+				{Label: "name", Properties: fields.FieldProperties{Type: "text"}},
+				{Label: "replicas", Properties: fields.FieldProperties{Type: "number"}},
+				{Label: "canary", Properties: fields.FieldProperties{Type: "boolean"}},
+				{Label: "regions", Properties: fields.FieldProperties{Type: "multiselect"}},
+			}}},
+		},
+	}
+
+	outputs, err := f.Outputs(url.Values{
+		"environment":         {"production"},
+		"services.4.name":     {"worker"},
+		"services.1.name":     {"api"},
+		"services.1.replicas": {"3"},
+		"services.1.canary":   {"true"},
+		"services.1.regions":  {"eu-west-1", "us-east-1"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []fields.Output{
+		{Label: "environment", Value: "production"},
+		{Label: "services", Value: `[{"canary":true,"name":"api","regions":["eu-west-1","us-east-1"],"replicas":3},{"canary":null,"name":"worker","regions":[],"replicas":null}]`},
+	}, outputs)
+}
@@ -0,0 +1,197 @@
+package fields
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/boasihq/interactive-inputs/internal/errors"
+	"github.com/sethvargo/go-githubactions"
+)
+
+// numberFieldType is a number, output in its canonical form
+var numberFieldType = FieldType{
+	Name:       "number",
+	Properties: []string{"placeholder", "minNumber", "maxNumber", "numberStep", "precision", "integer", "editableBy"},
+	Template:   "field-number",
+	Groupable:  true,
+	Normalise:  normaliseNumberField,
+	Validate: validateSubmittedValues(func(field Field, values []string) string {
+		return field.validateNumber(values[0])
+	}),
+	Output: encodeSubmittedValues(Field.numberOutput),
+	TypedValue: func(field Field, form url.Values) interface{} {
+		if number, err := strconv.ParseFloat(strings.TrimSpace(form.Get(field.Label)), 64); err == nil {
+			return number
+		}
+		return nil
+	},
+}
+
+// numberTolerance is the tolerance used when checking whether a number is a whole
+// multiple of a step or precision, to account for floating point rounding
+const numberTolerance float64 = 1e-9
+
+// FormatNumber returns the canonical representation of the number, using the
+// smallest number of decimal places needed to represent it exactly.
+func FormatNumber(number float64) string {
+	return strconv.FormatFloat(number, 'f', -1, 64)
+}
+
+// MinNumberValue returns the minimum value of a number field, or an empty string
+// if there is no minimum.
+func (p FieldProperties) MinNumberValue() string {
+	if p.NumberMin == nil {
+		return ""
+	}
+
+	return FormatNumber(*p.NumberMin)
+}
+
+// MaxNumberValue returns the maximum value of a number field, or an empty string
+// if there is no maximum.
+func (p FieldProperties) MaxNumberValue() string {
+	if p.NumberMax == nil {
+		return ""
+	}
+
+	return FormatNumber(*p.NumberMax)
+}
+
+// NumberStepValue returns the value of the step attribute for a number field's input.
+// Unless a step, precision or integer restriction is provided, any number is allowed.
+func (p FieldProperties) NumberStepValue() string {
+	switch {
+	case p.NumberStep != nil:
+		return FormatNumber(*p.NumberStep)
+	case p.Integer:
+		return "1"
+	case p.Precision != nil:
+		return FormatNumber(math.Pow10(-*p.Precision))
+	}
+
+	return "any"
+}
+
+// normaliseNumberField makes sure the bounds, step and precision of a number field are
+// consistent with each other, and that its default value is valid for the field.
+func normaliseNumberField(field *Field, action *githubactions.Action) error {
+	properties := field.Properties
+
+	if properties.NumberMin != nil && properties.NumberMax != nil && *properties.NumberMin > *properties.NumberMax {
+		action.Errorf("Invalid number limits provided for field '%s' - minNumber (%s) must not be greater than maxNumber (%s)", field.Label, properties.MinNumberValue(), properties.MaxNumberValue())
+		return errors.ErrInvalidNumberPropertiesProvided
+	}
+
+	if properties.NumberStep != nil && *properties.NumberStep <= 0 {
+		action.Errorf("Invalid numberStep provided for field '%s' - must be greater than 0", field.Label)
+		return errors.ErrInvalidNumberPropertiesProvided
+	}
+
+	if properties.Precision != nil && *properties.Precision < 0 {
+		action.Errorf("Invalid precision provided for field '%s' - must not be negative", field.Label)
+		return errors.ErrInvalidNumberPropertiesProvided
+	}
+
+	if properties.Integer && properties.NumberStep != nil && !isWholeNumber(*properties.NumberStep) {
+		action.Errorf("Invalid numberStep provided for field '%s' - must be a whole number for integer fields", field.Label)
+		return errors.ErrInvalidNumberPropertiesProvided
+	}
+
+	if properties.DefaultValue == "" || IsTemplated(properties.DefaultValue) {
+		return nil
+	}
+
+	if message := field.validateNumber(properties.DefaultValue); message != "" {
+		action.Errorf("Invalid default value provided for field '%s': %s", field.Label, message)
+		return errors.ErrInvalidDefaultValueProvided
+	}
+
+	return nil
+}
+
+// parseNumber parses the submitted value of a number field, rejecting values that
+// aren't finite numbers
+func parseNumber(value string) (float64, error) {
+	number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0, err
+	}
+
+	if math.IsNaN(number) || math.IsInf(number, 0) {
+		return 0, fmt.Errorf("'%s' is not a finite number", value)
+	}
+
+	return number, nil
+}
+
+// validateNumber returns a message describing why the value is not valid for the
+// number field, or an empty string if it is valid.
+func (field Field) validateNumber(value string) string {
+	properties := field.Properties
+
+	number, err := parseNumber(value)
+	if err != nil {
+		return "Must be a number"
+	}
+
+	if properties.Integer && !isWholeNumber(number) {
+		return "Must be a whole number"
+	}
+
+	if properties.NumberMin != nil && number < *properties.NumberMin {
+		return fmt.Sprintf("Must be at least %s", properties.MinNumberValue())
+	}
+
+	if properties.NumberMax != nil && number > *properties.NumberMax {
+		return fmt.Sprintf("Must be at most %s", properties.MaxNumberValue())
+	}
+
+	if properties.Precision != nil && !isWholeNumber(number*math.Pow10(*properties.Precision)) {
+		return fmt.Sprintf("Must have at most %d decimal place(s)", *properties.Precision)
+	}
+
+	if properties.NumberStep != nil {
+		// steps are counted from the minimum when there is one, as with the
+		// step attribute of number inputs
+		var base float64
+		if properties.NumberMin != nil {
+			base = *properties.NumberMin
+		}
+
+		if !isWholeNumber((number - base) / *properties.NumberStep) {
+			if base == 0 {
+				return fmt.Sprintf("Must be a multiple of %s", properties.NumberStepValue())
+			}
+			return fmt.Sprintf("Must be in steps of %s from %s", properties.NumberStepValue(), properties.MinNumberValue())
+		}
+	}
+
+	return ""
+}
+
+// numberOutput returns the canonical representation of the number field's value,
+// rounded to the field's precision when provided.
+func (field Field) numberOutput(value string) (string, error) {
+	number, err := parseNumber(value)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case field.Properties.Integer:
+		return strconv.FormatFloat(math.Round(number), 'f', 0, 64), nil
+	case field.Properties.Precision != nil:
+		return strconv.FormatFloat(number, 'f', *field.Properties.Precision, 64), nil
+	}
+
+	return FormatNumber(number), nil
+}
+
+// isWholeNumber returns whether the number is a whole number, within the tolerance
+// of floating point rounding
+func isWholeNumber(number float64) bool {
+	return math.Abs(number-math.Round(number)) < numberTolerance*math.Max(1, math.Abs(number))
+}
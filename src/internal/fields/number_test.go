@@ -0,0 +1,153 @@
+package fields_test
+
+import (
+	"bytes"
+	"net/url"
+	"testing"
+
+	"github.com/boasihq/interactive-inputs/internal/fields"
+	"github.com/sethvargo/go-githubactions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalStringIntoValidFieldsStruct_Number(t *testing.T) {
+	tests := []struct {
+		name               string
+		fieldsString       string
+		expectedError      bool
+		expectedProperties fields.FieldProperties
+		expectedOutput     string
+	}{
+		{
+			name:          "success - decimal bounds, step and precision",
+			fieldsString:  "fields:\n  - label: ratio\n    properties:\n      type: number\n      minNumber: 0\n      maxNumber: 1.5\n      numberStep: 0.25\n      precision: 2\n      defaultValue: '0.5'\n",
+			expectedError: false,
+			expectedProperties: fields.FieldProperties{
+				Type: "number", NumberMin: numberPtr(0), NumberMax: numberPtr(1.5), NumberStep: numberPtr(0.25), Precision: precisionPtr(2), DefaultValue: "0.5",
+			},
+		},
+		{
+			name:           "failed - min number greater than max number",
+			fieldsString:   "fields:\n  - label: ratio\n    properties:\n      type: number\n      minNumber: 2.5\n      maxNumber: 1\n",
+			expectedError:  true,
+			expectedOutput: "::error::Invalid number limits provided for field 'ratio' - minNumber (2.5) must not be greater than maxNumber (1)\n",
+		},
+		{
+			name:           "failed - step that isn't positive",
+			fieldsString:   "fields:\n  - label: ratio\n    properties:\n      type: number\n      numberStep: 0\n",
+			expectedError:  true,
+			expectedOutput: "::error::Invalid numberStep provided for field 'ratio' - must be greater than 0\n",
+		},
+		{
+			name:           "failed - decimal step for an integer field",
+			fieldsString:   "fields:\n  - label: replicas\n    properties:\n      type: number\n      integer: true\n      numberStep: 0.5\n",
+			expectedError:  true,
+			expectedOutput: "::error::Invalid numberStep provided for field 'replicas' - must be a whole number for integer fields\n",
+		},
+		{
+			name:           "failed - default value out of range",
+			fieldsString:   "fields:\n  - label: replicas\n    properties:\n      type: number\n      maxNumber: 3\n      defaultValue: '5'\n",
+			expectedError:  true,
+			expectedOutput: "::error::Invalid default value provided for field 'replicas': Must be at most 3\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actionLog := bytes.NewBuffer(nil)
+			action := githubactions.New(githubactions.WithWriter(actionLog))
+
+			result, err := fields.MarshalStringIntoValidFieldsStruct(tt.fieldsString, action)
+
+			assert.Equal(t, tt.expectedOutput, actionLog.String())
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedProperties, result.Fields[0].Properties)
+		})
+	}
+}
+
+func TestFields_ValidateNumber(t *testing.T) {
+	f := &fields.Fields{
+		Fields: []fields.Field{
+			{Label: "ratio", Properties: fields.FieldProperties{Type: "number", NumberMin: numberPtr(-1), NumberMax: numberPtr(1), Precision: precisionPtr(2)}},
+			{Label: "replicas", Properties: fields.FieldProperties{Type: "number", Integer: true, NumberMin: numberPtr(0)}},
+			{Label: "cpu", Properties: fields.FieldProperties{Type: "number", NumberStep: numberPtr(0.25)}},
+			{Label: "port", Properties: fields.FieldProperties{Type: "number", NumberMin: numberPtr(8000), NumberStep: numberPtr(10)}},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		form           url.Values
+		expectedErrors fields.FieldErrors
+	}{
+		{
+			name:           "valid values including zero and negative bounds",
+			form:           url.Values{"ratio": {"-1"}, "replicas": {"0"}, "cpu": {"0.75"}, "port": {"8030"}},
+			expectedErrors: fields.FieldErrors{},
+		},
+		{
+			name:           "values within floating point tolerance",
+			form:           url.Values{"ratio": {"0.29"}, "cpu": {"2.75"}},
+			expectedErrors: fields.FieldErrors{},
+		},
+		{
+			name: "invalid values",
+			form: url.Values{"ratio": {"0.123"}, "replicas": {"1.5"}, "cpu": {"0.3"}, "port": {"8005"}},
+			expectedErrors: fields.FieldErrors{
+				"ratio":    "Must have at most 2 decimal place(s)",
+				"replicas": "Must be a whole number",
+				"cpu":      "Must be a multiple of 0.25",
+				"port":     "Must be in steps of 10 from 8000",
+			},
+		},
+		{
+			name: "out of range and non numeric values",
+			form: url.Values{"ratio": {"1.01"}, "replicas": {"-1"}, "cpu": {"NaN"}, "port": {"Inf"}},
+			expectedErrors: fields.FieldErrors{
+				"ratio":    "Must be at most 1",
+				"replicas": "Must be at least 0",
+				"cpu":      "Must be a number",
+				"port":     "Must be a number",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedErrors, f.Validate(tt.form, "", fields.TemplateData{}))
+		})
+	}
+}
+
+func TestFields_OutputsNumber(t *testing.T) {
+	f := &fields.Fields{
+		Fields: []fields.Field{
+			{Label: "ratio", Properties: fields.FieldProperties{Type: "number", Precision: precisionPtr(2)}},
+			{Label: "replicas", Properties: fields.FieldProperties{Type: "number", Integer: true}},
+			{Label: "cpu", Properties: fields.FieldProperties{Type: "number"}},
+			{Label: "memory", Properties: fields.FieldProperties{Type: "number"}},
+		},
+	}
+
+	outputs, err := f.Outputs(url.Values{"ratio": {"0.5"}, "replicas": {" 003 "}, "cpu": {"1.50e1"}, "memory": {""}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []fields.Output{
+		{Label: "ratio", Value: "0.50"},
+		{Label: "replicas", Value: "3"},
+		{Label: "cpu", Value: "15"},
+		{Label: "memory", Value: ""},
+	}, outputs)
+}
+
+// precisionPtr returns a pointer to the given precision
+func precisionPtr(precision int) *int {
+	return &precision
+}
@@ -0,0 +1,220 @@
+package fields
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/boasihq/interactive-inputs/internal/errors"
+	"github.com/boasihq/interactive-inputs/internal/membership"
+	"github.com/sethvargo/go-githubactions"
+)
+
+// TeamChecker returns whether the user responding to the portal is a member of at least
+// one of the given teams
+type TeamChecker func(teams []string) (bool, error)
+
+// normalisePermissions makes sure the teams a field, and its choices, are limited to are
+// given as org/team-slug, removing the leading @ they are often written with. A required
+// field limited to teams must have a default value, as anyone who can't change the field
+// is given its default value.
+func normalisePermissions(field *Field, action *githubactions.Action) error {
+	for i, team := range field.Properties.EditableBy {
+		normalisedTeam, ok := membership.NormaliseTeam(team)
+		if !ok {
+			action.Errorf("Invalid team '%s' provided in the editableBy property of field '%s' - teams must be given as org/team-slug", team, field.Label)
+			return errors.ErrInvalidPermissionProvided
+		}
+		field.Properties.EditableBy[i] = normalisedTeam
+	}
+
+	if len(field.Properties.EditableBy) > 0 && field.Properties.Required && field.Properties.DefaultValue == "" {
+		action.Errorf("No defaultValue provided for required field '%s' - it is given to anyone who isn't a member of the editableBy teams", field.Label)
+		return errors.ErrInvalidPermissionProvided
+	}
+
+	for j := range field.Properties.Choices {
+		choice := &field.Properties.Choices[j]
+		for i, team := range choice.SelectableBy {
+			normalisedTeam, ok := membership.NormaliseTeam(team)
+			if !ok {
+				action.Errorf("Invalid team '%s' provided in the selectableBy property of choice '%s' of field '%s' - teams must be given as org/team-slug", team, choice.Value, field.Label)
+				return errors.ErrInvalidPermissionProvided
+			}
+			choice.SelectableBy[i] = normalisedTeam
+		}
+	}
+
+	return nil
+}
+
+// hasPermissions returns whether the field, or any of its choices, is limited to teams
+func (field Field) hasPermissions() bool {
+	if len(field.Properties.EditableBy) > 0 {
+		return true
+	}
+
+	for _, choice := range field.Properties.Choices {
+		if len(choice.SelectableBy) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasPermissions returns whether any of the fields, or their choices, are limited to teams
+func (f *Fields) HasPermissions() bool {
+	for _, field := range f.Fields {
+		if field.hasPermissions() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Locked returns whether the user viewing the portal can't change the field's value
+func (p FieldProperties) Locked() bool {
+	return p.locked
+}
+
+// WithPermissions returns a copy of the fields as they are seen by the user the given
+// checker checks the teams of. Fields the user can't edit are locked, and choices they
+// can't select are disabled, with a description of who can select them when the choice
+// has none.
+func (f *Fields) WithPermissions(isMember TeamChecker) (*Fields, error) {
+	permitted := *f
+	permitted.Fields = make([]Field, len(f.Fields))
+	copy(permitted.Fields, f.Fields)
+
+	for i := range permitted.Fields {
+		field := &permitted.Fields[i]
+		if !field.hasPermissions() {
+			continue
+		}
+
+		canEdit, err := field.canEdit(isMember)
+		if err != nil {
+			return nil, err
+		}
+		field.Properties.locked = !canEdit
+
+		field.Properties.Choices = append([]Choice(nil), field.Properties.Choices...)
+		for j := range field.Properties.Choices {
+			choice := &field.Properties.Choices[j]
+
+			canSelect, err := choice.canSelect(isMember)
+			if err != nil {
+				return nil, err
+			}
+
+			if !canSelect {
+				choice.Disabled = true
+				if choice.Description == "" {
+					choice.Description = fmt.Sprintf("Only %s can select this", teamList(choice.SelectableBy))
+				}
+			}
+		}
+	}
+
+	return &permitted, nil
+}
+
+// EnforcePermissions checks the submitted form values against the teams the fields, and
+// their choices, are limited to. Values submitted for fields the user can't edit, or for
+// choices they can't select, are described in the returned field errors. Fields the user
+// can't edit are given their default value, expanded with the provided data, in the form.
+// Read-only fields can't be changed by anyone, so they are left to EnforceReadOnly.
+func (f *Fields) EnforcePermissions(form url.Values, data TemplateData, isMember TeamChecker) (FieldErrors, error) {
+	var fieldErrors FieldErrors = make(FieldErrors)
+
+	for _, field := range f.Fields {
+		if !field.hasPermissions() || field.Properties.ReadOnly {
+			continue
+		}
+
+		canEdit, err := field.canEdit(isMember)
+		if err != nil {
+			return nil, err
+		}
+
+		if !canEdit {
+			if strings.TrimSpace(strings.Join(form[field.Label], "")) != "" {
+				fieldErrors[field.Label] = fmt.Sprintf("Only %s can change this field", teamList(field.Properties.EditableBy))
+				continue
+			}
+
+			defaultValue, err := ExpandTemplate(field.Properties.DefaultValue, data)
+			if err != nil {
+				return nil, fmt.Errorf("field '%s': %w", field.Label, err)
+			}
+
+			form.Del(field.Label)
+			if defaultValue != "" {
+				form.Set(field.Label, defaultValue)
+			}
+			continue
+		}
+
+		for _, choice := range field.Properties.Choices {
+			if !containsValue(form[field.Label], choice.Value) {
+				continue
+			}
+
+			canSelect, err := choice.canSelect(isMember)
+			if err != nil {
+				return nil, err
+			}
+
+			if !canSelect {
+				fieldErrors[field.Label] = fmt.Sprintf("Only %s can select '%s'", teamList(choice.SelectableBy), choice.Label)
+				break
+			}
+		}
+	}
+
+	return fieldErrors, nil
+}
+
+// canEdit returns whether the user can change the field's value
+func (field Field) canEdit(isMember TeamChecker) (bool, error) {
+	if len(field.Properties.EditableBy) == 0 {
+		return true, nil
+	}
+
+	return isMember(field.Properties.EditableBy)
+}
+
+// canSelect returns whether the user can select the choice
+func (c Choice) canSelect(isMember TeamChecker) (bool, error) {
+	if len(c.SelectableBy) == 0 {
+		return true, nil
+	}
+
+	return isMember(c.SelectableBy)
+}
+
+// containsValue returns whether any of the submitted values, which can be comma separated,
+// is the given value
+func containsValue(values []string, value string) bool {
+	for _, submitted := range values {
+		for _, part := range strings.Split(submitted, ",") {
+			if strings.TrimSpace(part) == value {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// teamList returns the teams as they are written in GitHub, i.e. members of @acme/sre or @acme/platform
+func teamList(teams []string) string {
+	var mentions []string = make([]string, 0, len(teams))
+	for _, team := range teams {
+		mentions = append(mentions, "@"+team)
+	}
+
+	return "members of " + strings.Join(mentions, " or ")
+}
@@ -0,0 +1,188 @@
+package fields_test
+
+import (
+	"bytes"
+	"net/url"
+	"testing"
+
+	"github.com/boasihq/interactive-inputs/internal/errors"
+	"github.com/boasihq/interactive-inputs/internal/fields"
+	"github.com/sethvargo/go-githubactions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalStringIntoValidFieldsStruct_Permissions(t *testing.T) {
+	tests := []struct {
+		name           string
+		fieldsString   string
+		expectedError  error
+		expectedOutput string
+	}{
+		{
+			name:         "success - field and choice limited to teams",
+			fieldsString: "fields:\n  - label: skip-canary\n    properties:\n      type: boolean\n      defaultValue: 'false'\n      editableBy: ['@acme/sre']\n  - label: environment\n    properties:\n      type: select\n      choices:\n        - staging\n        - value: prod\n          selectableBy: ['@acme/release-managers']\n",
+		},
+		{
+			name:           "failed - team without an organisation",
+			fieldsString:   "fields:\n  - label: skip-canary\n    properties:\n      type: boolean\n      editableBy: [sre]\n",
+			expectedError:  errors.ErrInvalidPermissionProvided,
+			expectedOutput: "::error::Invalid team 'sre' provided in the editableBy property of field 'skip-canary' - teams must be given as org/team-slug\n",
+		},
+		{
+			name:           "failed - choice team without an organisation",
+			fieldsString:   "fields:\n  - label: environment\n    properties:\n      type: select\n      choices:\n        - value: prod\n          selectableBy: [release-managers]\n",
+			expectedError:  errors.ErrInvalidPermissionProvided,
+			expectedOutput: "::error::Invalid team 'release-managers' provided in the selectableBy property of choice 'prod' of field 'environment' - teams must be given as org/team-slug\n",
+		},
+		{
+			name:           "failed - required field limited to teams without a default value",
+			fieldsString:   "fields:\n  - label: skip-canary\n    properties:\n      type: boolean\n      required: true\n      editableBy: [acme/sre]\n",
+			expectedError:  errors.ErrInvalidPermissionProvided,
+			expectedOutput: "::error::No defaultValue provided for required field 'skip-canary' - it is given to anyone who isn't a member of the editableBy teams\n",
+		},
+		{
+			name:           "failed - sub-field limited to teams",
+			fieldsString:   "fields:\n  - label: services\n    properties:\n      type: group\n      fields:\n        - label: version\n          properties:\n            type: text\n            editableBy: [acme/sre]\n",
+			expectedError:  errors.ErrInvalidPermissionProvided,
+			expectedOutput: "::error::Permissions can't be provided for sub-field 'version' of group field 'services' - only the fields of the form can be limited to teams\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actionLog := bytes.NewBuffer(nil)
+			action := githubactions.New(githubactions.WithWriter(actionLog))
+
+			result, err := fields.MarshalStringIntoValidFieldsStruct(tt.fieldsString, action)
+
+			assert.Equal(t, tt.expectedOutput, actionLog.String())
+			assert.ErrorIs(t, err, tt.expectedError)
+
+			if tt.expectedError == nil {
+				assert.True(t, result.HasPermissions())
+				assert.Equal(t, []string{"acme/sre"}, result.Fields[0].Properties.EditableBy)
+				assert.Equal(t, []string{"acme/release-managers"}, result.Fields[1].Properties.Choices[1].SelectableBy)
+			}
+		})
+	}
+}
+
+func TestFields_EnforcePermissions(t *testing.T) {
+	f := &fields.Fields{
+		Fields: []fields.Field{
+			{Label: "skip-canary", Properties: fields.FieldProperties{Type: "boolean", DefaultValue: "false", EditableBy: []string{"acme/sre"}}},
+			{Label: "environment", Properties: fields.FieldProperties{Type: "multiselect", Choices: []fields.Choice{
+				{Label: "Staging", Value: "staging"},
+				{Label: "Production", Value: "prod", SelectableBy: []string{"acme/release-managers"}},
+			}}},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		teams          []string
+		form           url.Values
+		expectedErrors fields.FieldErrors
+		expectedForm   url.Values
+	}{
+		{
+			name:           "member changes the field and selects the choice",
+			teams:          []string{"acme/sre", "acme/release-managers"},
+			form:           url.Values{"skip-canary": {"true"}, "environment": {"staging", "prod"}},
+			expectedErrors: fields.FieldErrors{},
+			expectedForm:   url.Values{"skip-canary": {"true"}, "environment": {"staging", "prod"}},
+		},
+		{
+			name:           "non-member is given the default value",
+			teams:          []string{},
+			form:           url.Values{"environment": {"staging"}},
+			expectedErrors: fields.FieldErrors{},
+			expectedForm:   url.Values{"skip-canary": {"false"}, "environment": {"staging"}},
+		},
+		{
+			name:           "non-member changes the field in a crafted submission",
+			teams:          []string{"acme/release-managers"},
+			form:           url.Values{"skip-canary": {"true"}, "environment": {"prod"}},
+			expectedErrors: fields.FieldErrors{"skip-canary": "Only members of @acme/sre can change this field"},
+			expectedForm:   url.Values{"skip-canary": {"true"}, "environment": {"prod"}},
+		},
+		{
+			name:           "non-member selects the choice",
+			teams:          []string{"acme/sre"},
+			form:           url.Values{"skip-canary": {"true"}, "environment": {"staging", "prod"}},
+			expectedErrors: fields.FieldErrors{"environment": "Only members of @acme/release-managers can select 'Production'"},
+			expectedForm:   url.Values{"skip-canary": {"true"}, "environment": {"staging", "prod"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fieldErrors, err := f.EnforcePermissions(tt.form, fields.TemplateData{}, memberOf(tt.teams...))
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedErrors, fieldErrors)
+			assert.Equal(t, tt.expectedForm, tt.form)
+		})
+	}
+}
+
+func TestFields_EnforcePermissionsOfReadOnlyField(t *testing.T) {
+	f := &fields.Fields{
+		Fields: []fields.Field{
+			{Label: "version", Properties: fields.FieldProperties{Type: "text", DefaultValue: "v1", ReadOnly: true, EditableBy: []string{"acme/sre"}}},
+		},
+	}
+
+	// the read-only field isn't submitted, so it is given its default value before the
+	// permissions are enforced, which doesn't count as a change by a non-member
+	form := url.Values{}
+	f.EnforceReadOnly(form, fields.TemplateData{})
+	fieldErrors, err := f.EnforcePermissions(form, fields.TemplateData{}, memberOf())
+
+	assert.NoError(t, err)
+	assert.Equal(t, fields.FieldErrors{}, fieldErrors)
+	assert.Equal(t, url.Values{"version": {"v1"}}, form)
+}
+
+func TestFields_WithPermissions(t *testing.T) {
+	f := &fields.Fields{
+		Fields: []fields.Field{
+			{Label: "skip-canary", Properties: fields.FieldProperties{Type: "boolean", EditableBy: []string{"acme/sre"}}},
+			{Label: "environment", Properties: fields.FieldProperties{Type: "select", Choices: []fields.Choice{
+				{Label: "Staging", Value: "staging"},
+				{Label: "Production", Value: "prod", SelectableBy: []string{"acme/release-managers"}},
+			}}},
+		},
+	}
+
+	permitted, err := f.WithPermissions(memberOf("acme/sre"))
+
+	assert.NoError(t, err)
+	assert.False(t, permitted.Fields[0].Properties.Locked())
+	assert.Equal(t, []string{"staging"}, permitted.Fields[1].Properties.ChoiceValues())
+	assert.Equal(t, "Only members of @acme/release-managers can select this", permitted.Fields[1].Properties.Choices[1].Description)
+
+	permitted, err = f.WithPermissions(memberOf("acme/release-managers"))
+
+	assert.NoError(t, err)
+	assert.True(t, permitted.Fields[0].Properties.Locked())
+	assert.Equal(t, []string{"staging", "prod"}, permitted.Fields[1].Properties.ChoiceValues())
+
+	// the fields the copies are made from are left as they are
+	assert.False(t, f.Fields[1].Properties.Choices[1].Disabled)
+}
+
+// memberOf returns a team checker for a user who is a member of the given teams
+func memberOf(memberTeams ...string) fields.TeamChecker {
+	return func(teams []string) (bool, error) {
+		for _, team := range teams {
+			for _, memberTeam := range memberTeams {
+				if team == memberTeam {
+					return true, nil
+				}
+			}
+		}
+
+		return false, nil
+	}
+}
@@ -0,0 +1,108 @@
+package fields
+
+import (
+	"os"
+	"strings"
+
+	"github.com/boasihq/interactive-inputs/internal/errors"
+	"github.com/boasihq/interactive-inputs/internal/toolbox"
+	"github.com/sethvargo/go-githubactions"
+)
+
+// defaultRegionMap is the name of the map displayed by a region field when none is provided
+const defaultRegionMap string = "world"
+
+// regionFieldType is one or more choices picked by selecting their regions on a map
+var regionFieldType = FieldType{
+	Name:       "region",
+	Properties: []string{"choices", "multiple", "map", "mapFile", "editableBy"},
+	Template:   "field-region",
+	Normalise:  normaliseRegionField,
+	Content:    renderRegionPicker,
+	Validate: validateSubmittedValues(func(field Field, values []string) string {
+		if field.Properties.Multiple {
+			return checkChoices(field, values)
+		}
+		return checkSingleChoice(field, values)
+	}),
+	Output: encodeSubmittedValues(nil),
+}
+
+// normaliseRegionField makes sure every choice of a region field is selected by a distinct
+// map region, standardising the region codes to upper case as they are used by the maps,
+// and resolves the path of the map file against the workspace.
+func normaliseRegionField(field *Field, action *githubactions.Action) error {
+	if len(field.Properties.Choices) == 0 {
+		action.Errorf("No choices provided for region field '%s'", field.Label)
+		return errors.ErrInvalidRegionProvided
+	}
+
+	var detectedRegions []string = make([]string, 0, len(field.Properties.Choices))
+	for i, choice := range field.Properties.Choices {
+		region := strings.ToUpper(strings.TrimSpace(choice.Region))
+		if region == "" {
+			action.Errorf("No region provided for choice '%s' of field '%s'", choice.Label, field.Label)
+			return errors.ErrInvalidRegionProvided
+		}
+
+		if toolbox.StringInSlice(region, detectedRegions) {
+			action.Errorf("Duplicate region detected for field '%s': '%s'", field.Label, region)
+			return errors.ErrInvalidRegionProvided
+		}
+
+		detectedRegions = append(detectedRegions, region)
+		field.Properties.Choices[i].Region = region
+	}
+
+	if field.Properties.Map == "" {
+		field.Properties.Map = defaultRegionMap
+	}
+
+	if field.Properties.MapFile != "" {
+		field.Properties.MapFile = WorkspacePath(action, field.Properties.MapFile)
+
+		if _, err := os.Stat(field.Properties.MapFile); err != nil {
+			action.Errorf("Unable to find the map file provided for field '%s': %s", field.Label, err)
+			return errors.ErrInvalidRegionProvided
+		}
+	}
+
+	if field.Properties.DefaultValue == "" || IsTemplated(field.Properties.DefaultValue) {
+		return nil
+	}
+
+	if message := field.validateValues(field.Properties.DefaultValues()); message != "" {
+		action.Errorf("Invalid default value provided for field '%s': %s", field.Label, message)
+		return errors.ErrInvalidDefaultValueProvided
+	}
+
+	return nil
+}
+
+// RegionValues returns the values of the region field's choices keyed by their map region
+func (p FieldProperties) RegionValues() map[string]string {
+	var regionValues map[string]string = make(map[string]string, len(p.Choices))
+	for _, choice := range p.Choices {
+		if !choice.Disabled {
+			regionValues[choice.Region] = choice.Value
+		}
+	}
+
+	return regionValues
+}
+
+// DefaultValues returns the field's default value split into the values it selects
+func (p FieldProperties) DefaultValues() []string {
+	if strings.TrimSpace(p.DefaultValue) == "" {
+		return []string{}
+	}
+
+	var values []string
+	for _, value := range strings.Split(p.DefaultValue, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
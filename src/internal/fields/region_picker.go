@@ -0,0 +1,52 @@
+package fields
+
+import (
+	"encoding/json"
+	"html/template"
+	"os"
+)
+
+// RegionPicker holds what's needed to display a region field's map
+type RegionPicker struct {
+	Map      string            `json:"map"`
+	Regions  map[string]string `json:"regions"`
+	Multiple bool              `json:"multiple"`
+	Selected []string          `json:"selected"`
+
+	// MapScript is the map definition loaded from the field's map file, if any
+	MapScript template.JS `json:"-"`
+}
+
+// JSON returns the picker encoded as JSON, so it can be passed to the region picker component
+func (p RegionPicker) JSON() (string, error) {
+	encoded, err := json.Marshal(p)
+	if err != nil {
+		return "", err
+	}
+
+	return string(encoded), nil
+}
+
+// renderRegionPicker loads the map definition of the region field, and picks out the
+// regions that can be selected. The picker is returned without a map if the map
+// definition can't be loaded, so the regions can still be picked from the list.
+func renderRegionPicker(field Field) (interface{}, error) {
+	picker := &RegionPicker{
+		Map:      field.Properties.Map,
+		Regions:  field.Properties.RegionValues(),
+		Multiple: field.Properties.Multiple,
+		Selected: field.Properties.DefaultValues(),
+	}
+
+	if field.Properties.MapFile == "" {
+		return picker, nil
+	}
+
+	content, err := os.ReadFile(field.Properties.MapFile)
+	if err != nil {
+		return picker, err
+	}
+	picker.MapScript = template.JS(content)
+
+	return picker, nil
+}
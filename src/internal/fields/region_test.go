@@ -0,0 +1,160 @@
+package fields_test
+
+import (
+	"bytes"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/boasihq/interactive-inputs/internal/fields"
+	"github.com/sethvargo/go-githubactions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalStringIntoValidFieldsStruct_Region(t *testing.T) {
+	workspace := t.TempDir()
+	err := os.WriteFile(filepath.Join(workspace, "world.js"), []byte(`jsVectorMap.addMap("world", {})`), 0o644)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name               string
+		fieldsString       string
+		expectedError      bool
+		expectedProperties fields.FieldProperties
+		expectedOutput     string
+	}{
+		{
+			name:          "success - region codes are upper cased and the map file is resolved",
+			fieldsString:  "fields:\n  - label: regions\n    properties:\n      type: region\n      multiple: true\n      mapFile: world.js\n      defaultValue: eu-central-1,us-east-1\n      choices:\n        - label: Frankfurt\n          value: eu-central-1\n          region: de\n        - value: us-east-1\n          region: US\n",
+			expectedError: false,
+			expectedProperties: fields.FieldProperties{
+				Type:         "region",
+				Multiple:     true,
+				Map:          "world",
+				MapFile:      filepath.Join(workspace, "world.js"),
+				DefaultValue: "eu-central-1,us-east-1",
+				Choices: []fields.Choice{
+					{Label: "Frankfurt", Value: "eu-central-1", Region: "DE"},
+					{Label: "us-east-1", Value: "us-east-1", Region: "US"},
+				},
+			},
+		},
+		{
+			name:           "failed - no choices",
+			fieldsString:   "fields:\n  - label: regions\n    properties:\n      type: region\n",
+			expectedError:  true,
+			expectedOutput: "::error::No choices provided for region field 'regions'\n",
+		},
+		{
+			name:           "failed - choice without a region",
+			fieldsString:   "fields:\n  - label: regions\n    properties:\n      type: region\n      choices: [eu-central-1]\n",
+			expectedError:  true,
+			expectedOutput: "::error::No region provided for choice 'eu-central-1' of field 'regions'\n",
+		},
+		{
+			name:           "failed - choices sharing a region",
+			fieldsString:   "fields:\n  - label: regions\n    properties:\n      type: region\n      choices:\n        - value: eu-central-1\n          region: DE\n        - value: eu-central-2\n          region: de\n",
+			expectedError:  true,
+			expectedOutput: "::error::Duplicate region detected for field 'regions': 'DE'\n",
+		},
+		{
+			name:           "failed - missing map file",
+			fieldsString:   "fields:\n  - label: regions\n    properties:\n      type: region\n      mapFile: europe.js\n      choices:\n        - value: eu-central-1\n          region: DE\n",
+			expectedError:  true,
+			expectedOutput: "::error::Unable to find the map file provided for field 'regions': stat " + filepath.Join(workspace, "europe.js") + ": no such file or directory\n",
+		},
+		{
+			name:           "failed - several default values for a single region field",
+			fieldsString:   "fields:\n  - label: regions\n    properties:\n      type: region\n      defaultValue: eu-central-1,us-east-1\n      choices:\n        - value: eu-central-1\n          region: DE\n        - value: us-east-1\n          region: US\n",
+			expectedError:  true,
+			expectedOutput: "::error::Invalid default value provided for field 'regions': Only one option can be selected\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actionLog := bytes.NewBuffer(nil)
+			action := githubactions.New(
+				githubactions.WithWriter(actionLog),
+				githubactions.WithGetenv(func(key string) string {
+					if key == "GITHUB_WORKSPACE" {
+						return workspace
+					}
+					return ""
+				}),
+			)
+
+			result, err := fields.MarshalStringIntoValidFieldsStruct(tt.fieldsString, action)
+
+			assert.Equal(t, tt.expectedOutput, actionLog.String())
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedProperties, result.Fields[0].Properties)
+		})
+	}
+}
+
+func TestFields_ValidateRegion(t *testing.T) {
+	choices := []fields.Choice{
+		{Label: "Frankfurt", Value: "eu-central-1", Region: "DE"},
+		{Label: "N. Virginia", Value: "us-east-1", Region: "US"},
+	}
+	f := &fields.Fields{
+		Fields: []fields.Field{
+			{Label: "primary", Properties: fields.FieldProperties{Type: "region", Required: true, Choices: choices}},
+			{Label: "replicas", Properties: fields.FieldProperties{Type: "region", Multiple: true, Choices: choices}},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		form           url.Values
+		expectedErrors fields.FieldErrors
+	}{
+		{
+			name:           "valid regions",
+			form:           url.Values{"primary": {"eu-central-1"}, "replicas": {"eu-central-1", "us-east-1"}},
+			expectedErrors: fields.FieldErrors{},
+		},
+		{
+			name: "several regions for a single region field and an unknown region",
+			form: url.Values{"primary": {"eu-central-1", "us-east-1"}, "replicas": {"ap-south-1"}},
+			expectedErrors: fields.FieldErrors{
+				"primary":  "Only one option can be selected",
+				"replicas": "'ap-south-1' is not one of the available options",
+			},
+		},
+		{
+			name:           "missing required region",
+			form:           url.Values{},
+			expectedErrors: fields.FieldErrors{"primary": "This field is required"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedErrors, f.Validate(tt.form, "", fields.TemplateData{}))
+		})
+	}
+}
+
+func TestFieldProperties_RegionValues(t *testing.T) {
+	properties := fields.FieldProperties{
+		Type:         "region",
+		DefaultValue: " eu-central-1, ,us-east-1",
+		Choices: []fields.Choice{
+			{Label: "Frankfurt", Value: "eu-central-1", Region: "DE"},
+			{Label: "N. Virginia", Value: "us-east-1", Region: "US"},
+			{Label: "Mumbai", Value: "ap-south-1", Region: "IN", Disabled: true},
+		},
+	}
+
+	assert.Equal(t, map[string]string{"DE": "eu-central-1", "US": "us-east-1"}, properties.RegionValues())
+	assert.Equal(t, []string{"eu-central-1", "us-east-1"}, properties.DefaultValues())
+}
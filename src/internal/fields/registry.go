@@ -0,0 +1,243 @@
+package fields
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/boasihq/interactive-inputs/internal/toolbox"
+	"github.com/sethvargo/go-githubactions"
+)
+
+// FieldType describes a type of field, bringing together everything needed to support
+// it: the properties it accepts, how its config is checked when the action starts, the
+// template partial that renders it in the portal, and how the values submitted for it
+// are validated and encoded as its output.
+type FieldType struct {
+
+	// Name is the value of the type property that selects the field type
+	Name string
+
+	// Properties are the type-specific properties supported by the type, in addition
+	// to those supported by every type. Other type-specific properties are reported as
+	// unsupported when the config is parsed.
+	Properties []string
+
+	// Template is the name of the template partial that renders fields of the type
+	Template string
+
+	// DisplayOnly is whether fields of the type only display content and don't capture
+	// a value, so they aren't validated and don't have an output
+	DisplayOnly bool
+
+	// Groupable is whether the type can be used for the sub-fields of a group field
+	Groupable bool
+
+	// Normalise checks the type-specific properties of a field when the config is parsed,
+	// standardising them in place. If nil, the properties aren't checked.
+	Normalise func(field *Field, action *githubactions.Action) error
+
+	// Validate returns a description of each problem with the values submitted for a
+	// field, keyed by the name of the offending input. If nil, the values aren't validated.
+	Validate func(field Field, form url.Values, data TemplateData) FieldErrors
+
+	// Output encodes the values submitted for a field as its output, returning whether
+	// the field has an output. If nil, the field doesn't have an output.
+	Output func(field Field, form url.Values) (string, bool, error)
+
+	// TypedValue converts the values submitted for a field to the JSON type that best
+	// represents it, so they can be compared in rules and output by group fields. If nil,
+	// the trimmed first value is used, or nil when no value was submitted.
+	TypedValue func(field Field, form url.Values) interface{}
+
+	// Content prepares what a field of the type displays in the portal, which is passed to
+	// its template partial. Content returned alongside an error is still displayed. If
+	// nil, the field doesn't display any prepared content.
+	Content func(field Field) (interface{}, error)
+}
+
+var (
+
+	// fieldTypes are the registered field types keyed by name
+	fieldTypes map[string]FieldType = make(map[string]FieldType)
+
+	// fieldTypeNames are the names of the registered field types in the order they were registered
+	fieldTypeNames []string
+)
+
+func init() {
+	for _, fieldType := range []FieldType{
+		textFieldType,
+		textareaFieldType,
+		numberFieldType,
+		booleanFieldType,
+		selectFieldType,
+		multiselectFieldType,
+		fileFieldType,
+		multifileFieldType,
+		groupFieldType,
+		jsonFieldType,
+		yamlFieldType,
+		confirmFieldType,
+		markdownFieldType,
+		filePreviewFieldType,
+		diffFieldType,
+		chartFieldType,
+		regionFieldType,
+	} {
+		RegisterFieldType(fieldType)
+	}
+}
+
+// RegisterFieldType makes the field type available to the fields config. It panics if the
+// type doesn't have a name or template, or a type with the same name is already registered.
+func RegisterFieldType(fieldType FieldType) {
+	fieldType.Name = toolbox.StringStandardisedToLower(fieldType.Name)
+
+	if fieldType.Name == "" || fieldType.Template == "" {
+		panic("fields: a field type must have a name and template")
+	}
+
+	if _, ok := fieldTypes[fieldType.Name]; ok {
+		panic(fmt.Sprintf("fields: field type '%s' is already registered", fieldType.Name))
+	}
+
+	fieldTypes[fieldType.Name] = fieldType
+	fieldTypeNames = append(fieldTypeNames, fieldType.Name)
+}
+
+// LookupFieldType returns the registered field type with the given name, and whether it exists
+func LookupFieldType(name string) (FieldType, bool) {
+	fieldType, ok := fieldTypes[toolbox.StringStandardisedToLower(name)]
+	return fieldType, ok
+}
+
+// FieldTypeNames returns the names of the registered field types that match the filter,
+// in the order they were registered. All of the names are returned when the filter is nil.
+func FieldTypeNames(filter func(fieldType FieldType) bool) []string {
+	var names []string = make([]string, 0, len(fieldTypeNames))
+	for _, name := range fieldTypeNames {
+		if filter == nil || filter(fieldTypes[name]) {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// FieldType returns the registered type of the field. Fields are only created with
+// registered types, so an unregistered type is returned without any behaviour.
+func (field Field) FieldType() FieldType {
+	fieldType, ok := LookupFieldType(field.Properties.Type)
+	if !ok {
+		return FieldType{Name: field.Properties.Type}
+	}
+
+	return fieldType
+}
+
+// TypedValue returns the value submitted for the field converted to the JSON type that
+// best represents it, or nil when no value was submitted
+func (field Field) TypedValue(form url.Values) interface{} {
+	if typedValue := field.FieldType().TypedValue; typedValue != nil {
+		return typedValue(field, form)
+	}
+
+	values, ok := form[field.Label]
+	if !ok {
+		return nil
+	}
+
+	if len(values) == 0 {
+		return ""
+	}
+
+	return strings.TrimSpace(values[0])
+}
+
+// Content returns what the field displays in the portal, prepared by its type, and whether
+// the type prepares any
+func (field Field) Content() (interface{}, bool, error) {
+	content := field.FieldType().Content
+	if content == nil {
+		return nil, false, nil
+	}
+
+	value, err := content(field)
+	return value, true, err
+}
+
+// typesSupportingProperty returns the names of the registered field types that support
+// the type-specific property, in the order they were registered, and whether any do
+func typesSupportingProperty(property string) ([]string, bool) {
+	var names []string
+	for _, name := range fieldTypeNames {
+		if toolbox.StringInSlice(property, fieldTypes[name].Properties) {
+			names = append(names, name)
+		}
+	}
+
+	return names, len(names) > 0
+}
+
+// validateSubmittedValues returns a validator for a type whose values are submitted under
+// the field's label. Blank values are ignored, a required field must have at least one
+// value, and the remaining values are checked by the given function when there are any.
+// Read-only fields are displayed disabled, so nothing is submitted for them, and are given
+// their default value instead, which isn't validated.
+func validateSubmittedValues(check func(field Field, values []string) string) func(Field, url.Values, TemplateData) FieldErrors {
+	return func(field Field, form url.Values, _ TemplateData) FieldErrors {
+		var fieldErrors FieldErrors = make(FieldErrors)
+
+		if field.Properties.ReadOnly {
+			return fieldErrors
+		}
+
+		var nonEmptyValues []string = make([]string, 0, len(form[field.Label]))
+		for _, value := range form[field.Label] {
+			if strings.TrimSpace(value) != "" {
+				nonEmptyValues = append(nonEmptyValues, value)
+			}
+		}
+
+		switch {
+		case len(nonEmptyValues) == 0 && field.Properties.Required:
+			fieldErrors[field.Label] = "This field is required"
+		case len(nonEmptyValues) > 0 && check != nil:
+			if message := check(field, nonEmptyValues); message != "" {
+				fieldErrors[field.Label] = message
+			}
+		}
+
+		return fieldErrors
+	}
+}
+
+// encodeSubmittedValues returns an output encoder for a type whose values are submitted
+// under the field's label. Fields without a submitted value don't have an output. Multiple
+// values are joined by commas, unless the values are encoded by the given function, in
+// which case blank values have an empty output as they have no canonical form.
+func encodeSubmittedValues(encode func(field Field, value string) (string, error)) func(Field, url.Values) (string, bool, error) {
+	return func(field Field, form url.Values) (string, bool, error) {
+		values, ok := form[field.Label]
+		if !ok {
+			return "", false, nil
+		}
+
+		var value string = strings.Join(values, ",")
+		if encode == nil {
+			return value, true, nil
+		}
+
+		if strings.TrimSpace(value) == "" {
+			return "", true, nil
+		}
+
+		value, err := encode(field, values[0])
+		if err != nil {
+			return "", false, err
+		}
+
+		return value, true, nil
+	}
+}
@@ -0,0 +1,271 @@
+package fields_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/boasihq/interactive-inputs/internal/fields"
+	"github.com/sethvargo/go-githubactions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFieldTypes(t *testing.T) {
+	tests := []struct {
+		name            string
+		field           fields.Field
+		form            url.Values
+		expectedErrors  fields.FieldErrors
+		expectedOutputs []fields.Output
+	}{
+		{
+			name:            "text - value is output as submitted",
+			field:           fields.Field{Label: "name", Properties: fields.FieldProperties{Type: "text", MaxLength: 5}},
+			form:            url.Values{"name": {"héllo"}},
+			expectedErrors:  fields.FieldErrors{},
+			expectedOutputs: []fields.Output{{Label: "name", Value: "héllo"}},
+		},
+		{
+			name:           "text - value longer than the maximum length",
+			field:          fields.Field{Label: "name", Properties: fields.FieldProperties{Type: "text", MaxLength: 5}},
+			form:           url.Values{"name": {"too long"}},
+			expectedErrors: fields.FieldErrors{"name": "Must be at most 5 characters long"},
+		},
+		{
+			name:            "textarea - value is output as submitted",
+			field:           fields.Field{Label: "notes", Properties: fields.FieldProperties{Type: "textarea"}},
+			form:            url.Values{"notes": {"line one\nline two"}},
+			expectedErrors:  fields.FieldErrors{},
+			expectedOutputs: []fields.Output{{Label: "notes", Value: "line one\nline two"}},
+		},
+		{
+			name:           "textarea - blank required value",
+			field:          fields.Field{Label: "notes", Properties: fields.FieldProperties{Type: "textarea", Required: true}},
+			form:           url.Values{"notes": {"  "}},
+			expectedErrors: fields.FieldErrors{"notes": "This field is required"},
+		},
+		{
+			name:            "number - value is output in its canonical form",
+			field:           fields.Field{Label: "replicas", Properties: fields.FieldProperties{Type: "number", Integer: true}},
+			form:            url.Values{"replicas": {" 003 "}},
+			expectedErrors:  fields.FieldErrors{},
+			expectedOutputs: []fields.Output{{Label: "replicas", Value: "3"}},
+		},
+		{
+			name:           "number - value that isn't a number",
+			field:          fields.Field{Label: "replicas", Properties: fields.FieldProperties{Type: "number"}},
+			form:           url.Values{"replicas": {"three"}},
+			expectedErrors: fields.FieldErrors{"replicas": "Must be a number"},
+		},
+		{
+			name:            "boolean - value is output as submitted",
+			field:           fields.Field{Label: "notify", Properties: fields.FieldProperties{Type: "boolean"}},
+			form:            url.Values{"notify": {"false"}},
+			expectedErrors:  fields.FieldErrors{},
+			expectedOutputs: []fields.Output{{Label: "notify", Value: "false"}},
+		},
+		{
+			name:           "boolean - value that isn't true or false",
+			field:          fields.Field{Label: "notify", Properties: fields.FieldProperties{Type: "boolean"}},
+			form:           url.Values{"notify": {"yes"}},
+			expectedErrors: fields.FieldErrors{"notify": "Must be either true or false"},
+		},
+		{
+			name:            "select - choice value is output",
+			field:           fields.Field{Label: "environment", Properties: fields.FieldProperties{Type: "select", Choices: []fields.Choice{{Label: "Staging", Value: "stg"}}}},
+			form:            url.Values{"environment": {"stg"}},
+			expectedErrors:  fields.FieldErrors{},
+			expectedOutputs: []fields.Output{{Label: "environment", Value: "stg"}},
+		},
+		{
+			name:           "select - more than one choice",
+			field:          fields.Field{Label: "environment", Properties: fields.FieldProperties{Type: "select", Choices: []fields.Choice{{Label: "Staging", Value: "stg"}}}},
+			form:           url.Values{"environment": {"stg", "stg"}},
+			expectedErrors: fields.FieldErrors{"environment": "Only one option can be selected"},
+		},
+		{
+			name:            "multiselect - choice values are joined by commas",
+			field:           fields.Field{Label: "regions", Properties: fields.FieldProperties{Type: "multiselect", Choices: []fields.Choice{{Label: "eu-west-1", Value: "eu-west-1"}, {Label: "us-east-1", Value: "us-east-1"}}}},
+			form:            url.Values{"regions": {"eu-west-1", "us-east-1"}},
+			expectedErrors:  fields.FieldErrors{},
+			expectedOutputs: []fields.Output{{Label: "regions", Value: "eu-west-1,us-east-1"}},
+		},
+		{
+			name:           "multiselect - value that isn't a choice",
+			field:          fields.Field{Label: "regions", Properties: fields.FieldProperties{Type: "multiselect", Choices: []fields.Choice{{Label: "eu-west-1", Value: "eu-west-1"}}}},
+			form:           url.Values{"regions": {"eu-west-1", "ap-south-1"}},
+			expectedErrors: fields.FieldErrors{"regions": "'ap-south-1' is not one of the available options"},
+		},
+		{
+			name:            "file - uploads are held in the runner's cache so there is no output",
+			field:           fields.Field{Label: "upload", Properties: fields.FieldProperties{Type: "file", Required: true}},
+			form:            url.Values{"upload": {"build.zip"}},
+			expectedErrors:  fields.FieldErrors{},
+			expectedOutputs: []fields.Output{},
+		},
+		{
+			name:            "multifile - uploads are held in the runner's cache so there is no output",
+			field:           fields.Field{Label: "uploads", Properties: fields.FieldProperties{Type: "multifile"}},
+			form:            url.Values{"uploads": {"a.zip", "b.zip"}},
+			expectedErrors:  fields.FieldErrors{},
+			expectedOutputs: []fields.Output{},
+		},
+		{
+			name: "group - items are output as a JSON array",
+			field: fields.Field{Label: "services", Properties: fields.FieldProperties{Type: "group", Fields: []fields.Field{
+				{Label: "name", Properties: fields.FieldProperties{Type: "text"}},
+			}}},
+			form:            url.Values{"services.0.name": {"api"}},
+			expectedErrors:  fields.FieldErrors{},
+			expectedOutputs: []fields.Output{{Label: "services", Value: `[{"name":"api"}]`}},
+		},
+		{
+			name: "group - invalid sub-field value",
+			field: fields.Field{Label: "services", Properties: fields.FieldProperties{Type: "group", Fields: []fields.Field{
+				{Label: "replicas", Properties: fields.FieldProperties{Type: "number"}},
+			}}},
+			form:           url.Values{"services.0.replicas": {"three"}},
+			expectedErrors: fields.FieldErrors{"services.0.replicas": "Must be a number"},
+		},
+		{
+			name:            "json - value is output compacted",
+			field:           fields.Field{Label: "overrides", Properties: fields.FieldProperties{Type: "json"}},
+			form:            url.Values{"overrides": {"{\n  \"replicas\": 3\n}"}},
+			expectedErrors:  fields.FieldErrors{},
+			expectedOutputs: []fields.Output{{Label: "overrides", Value: `{"replicas":3}`}},
+		},
+		{
+			name:           "json - value that isn't JSON",
+			field:          fields.Field{Label: "overrides", Properties: fields.FieldProperties{Type: "json"}},
+			form:           url.Values{"overrides": {"{replicas: 3}"}},
+			expectedErrors: fields.FieldErrors{"overrides": "Must be valid JSON: invalid character 'r' looking for beginning of object key string"},
+		},
+		{
+			name:            "yaml - value is output as JSON",
+			field:           fields.Field{Label: "values", Properties: fields.FieldProperties{Type: "yaml"}},
+			form:            url.Values{"values": {"ports: [80, 443]"}},
+			expectedErrors:  fields.FieldErrors{},
+			expectedOutputs: []fields.Output{{Label: "values", Value: `{"ports":[80,443]}`}},
+		},
+		{
+			name:            "confirm - matching phrase guards the submission so there is no output",
+			field:           fields.Field{Label: "sure", Properties: fields.FieldProperties{Type: "confirm", Phrase: "deploy"}},
+			form:            url.Values{"sure": {"deploy"}},
+			expectedErrors:  fields.FieldErrors{},
+			expectedOutputs: []fields.Output{},
+		},
+		{
+			name:           "confirm - phrase that doesn't match",
+			field:          fields.Field{Label: "sure", Properties: fields.FieldProperties{Type: "confirm", Phrase: "deploy"}},
+			form:           url.Values{"sure": {"Deploy"}},
+			expectedErrors: fields.FieldErrors{"sure": "Must exactly match 'deploy'"},
+		},
+		{
+			name:            "region - choice value is output",
+			field:           fields.Field{Label: "region", Properties: fields.FieldProperties{Type: "region", Choices: []fields.Choice{{Label: "Germany", Value: "de", Region: "DE"}}}},
+			form:            url.Values{"region": {"de"}},
+			expectedErrors:  fields.FieldErrors{},
+			expectedOutputs: []fields.Output{{Label: "region", Value: "de"}},
+		},
+		{
+			name:           "region - more than one choice without multiple",
+			field:          fields.Field{Label: "region", Properties: fields.FieldProperties{Type: "region", Choices: []fields.Choice{{Label: "Germany", Value: "de", Region: "DE"}}}},
+			form:           url.Values{"region": {"de", "de"}},
+			expectedErrors: fields.FieldErrors{"region": "Only one option can be selected"},
+		},
+	}
+
+	for _, displayType := range fields.FieldTypeNames(func(fieldType fields.FieldType) bool { return fieldType.DisplayOnly }) {
+		tests = append(tests, struct {
+			name            string
+			field           fields.Field
+			form            url.Values
+			expectedErrors  fields.FieldErrors
+			expectedOutputs []fields.Output
+		}{
+			name:            fmt.Sprintf("%s - display only so it isn't validated and there is no output", displayType),
+			field:           fields.Field{Label: "content", Properties: fields.FieldProperties{Type: displayType, Required: true}},
+			form:            url.Values{"content": {"anything"}},
+			expectedErrors:  fields.FieldErrors{},
+			expectedOutputs: []fields.Output{},
+		})
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fields.Fields{Fields: []fields.Field{tt.field}}
+
+			assert.Equal(t, tt.expectedErrors, f.Validate(tt.form, "", fields.TemplateData{}))
+
+			if len(tt.expectedErrors) > 0 {
+				return
+			}
+
+			outputs, err := f.Outputs(tt.form)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedOutputs, outputs)
+		})
+	}
+}
+
+func TestFieldTypes_Registered(t *testing.T) {
+	for _, name := range fields.FieldTypeNames(nil) {
+		t.Run(name, func(t *testing.T) {
+			fieldType, ok := fields.LookupFieldType(name)
+			assert.True(t, ok)
+			assert.Equal(t, name, fieldType.Name)
+			assert.NotEmpty(t, fieldType.Template)
+		})
+	}
+}
+
+// semverFieldType is a custom field type registered by the tests
+var semverFieldType = fields.FieldType{
+	Name:       "Semver",
+	Properties: []string{"placeholder"},
+	Template:   "field-text",
+	Validate: func(field fields.Field, form url.Values, _ fields.TemplateData) fields.FieldErrors {
+		var fieldErrors fields.FieldErrors = make(fields.FieldErrors)
+		if value := form.Get(field.Label); strings.Count(value, ".") != 2 {
+			fieldErrors[field.Label] = "Must be a semantic version"
+		}
+		return fieldErrors
+	},
+	Output: func(field fields.Field, form url.Values) (string, bool, error) {
+		return "v" + strings.TrimPrefix(form.Get(field.Label), "v"), true, nil
+	},
+}
+
+func TestRegisterFieldType(t *testing.T) {
+	// types are registered for the lifetime of the process, so only once across repeated runs
+	if _, ok := fields.LookupFieldType(semverFieldType.Name); !ok {
+		fields.RegisterFieldType(semverFieldType)
+	}
+
+	actionLog := bytes.NewBuffer(nil)
+	action := githubactions.New(githubactions.WithWriter(actionLog))
+
+	f, err := fields.MarshalStringIntoValidFieldsStruct("fields:\n  - label: version\n    properties:\n      type: semver\n      placeholder: 1.0.0\n", action)
+
+	assert.NoError(t, err)
+	assert.Empty(t, actionLog.String())
+	assert.Equal(t, "field-text", f.Fields[0].FieldType().Template)
+	assert.Equal(t, fields.FieldErrors{"version": "Must be a semantic version"}, f.Validate(url.Values{"version": {"1.0"}}, "", fields.TemplateData{}))
+
+	outputs, err := f.Outputs(url.Values{"version": {"1.2.3"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []fields.Output{{Label: "version", Value: "v1.2.3"}}, outputs)
+
+	_, err = fields.MarshalStringIntoValidFieldsStruct("fields:\n  - label: version\n    properties:\n      type: semver\n      maxLength: 5\n", action)
+	assert.Error(t, err)
+	assert.Contains(t, actionLog.String(), "::error::Property 'maxLength' isn't supported by the semver type of field 'version'")
+
+	assert.Panics(t, func() {
+		fields.RegisterFieldType(fields.FieldType{Name: "semver", Template: "field-text"})
+	})
+	assert.Panics(t, func() {
+		fields.RegisterFieldType(fields.FieldType{Name: "untemplated"})
+	})
+}
@@ -0,0 +1,102 @@
+package fields
+
+import (
+	"net/url"
+)
+
+// MaskedValue is shown in place of the value of a secret field when it is reviewed
+const MaskedValue string = "••••••••"
+
+// ReviewItem is the value of a field shown for review before the portal is submitted
+type ReviewItem struct {
+
+	// Label is the label of the field, which its value is set as the output of
+	Label string
+
+	// Display is the name the field is displayed with, or its label when it doesn't have one
+	Display string
+
+	// Value is the value exactly as it will be set as the output, masked when the field
+	// is secret
+	Value string
+
+	// Secret is true when the value is masked
+	Secret bool
+
+	// Changed is true when the value differs from the field's default value
+	Changed bool
+
+	// Uploaded is true for file fields, whose files are held in the runner's cache rather
+	// than submitted, so they aren't included in the value
+	Uploaded bool
+}
+
+// Review returns the values of the fields with an output, along with the file fields, in
+// the order the fields are defined, so they can be reviewed before they are submitted. The
+// data is used to expand templated default values, with its answers taken from the
+// submitted values.
+func (f *Fields) Review(form url.Values, data TemplateData) ([]ReviewItem, error) {
+	var items []ReviewItem = make([]ReviewItem, 0, len(f.Fields))
+
+	data.Answers = f.Answers(form)
+
+	for _, field := range f.Fields {
+		fieldType := field.FieldType()
+
+		// fields are shown by their label when they don't have a display name
+		display := field.Properties.Display
+		if display == "" {
+			display = field.Label
+		}
+
+		if fieldType.Name == fileFieldType.Name || fieldType.Name == multifileFieldType.Name {
+			items = append(items, ReviewItem{Label: field.Label, Display: display, Uploaded: true})
+			continue
+		}
+
+		value, ok, err := field.output(form)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			continue
+		}
+
+		item := ReviewItem{
+			Label:   field.Label,
+			Display: display,
+			Value:   value,
+			Secret:  field.Properties.Secret,
+			Changed: value != field.defaultOutput(data),
+		}
+
+		if item.Secret {
+			item.Value = MaskedValue
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// defaultOutput returns the field's default value encoded in the same way as its output,
+// expanded with the data when it is templated
+func (field Field) defaultOutput(data TemplateData) string {
+	defaultValue := field.Properties.DefaultValue
+
+	if IsTemplated(defaultValue) {
+		expanded, err := ExpandTemplate(defaultValue, data)
+		if err == nil {
+			defaultValue = expanded
+		}
+	}
+
+	value, ok, err := field.output(url.Values{field.Label: {defaultValue}})
+	if err != nil || !ok {
+		return ""
+	}
+
+	return value
+}
@@ -0,0 +1,53 @@
+package fields_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/boasihq/interactive-inputs/internal/fields"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFields_Review(t *testing.T) {
+	precision := 2
+
+	f := &fields.Fields{
+		Fields: []fields.Field{
+			{Label: "name", Properties: fields.FieldProperties{Display: "Name", Type: "text", DefaultValue: "alice"}},
+			{Label: "greeting", Properties: fields.FieldProperties{Display: "Greeting", Type: "text", DefaultValue: "hello {{ .Answers.name }}"}},
+			{Label: "token", Properties: fields.FieldProperties{Display: "Token", Type: "text", Secret: true}},
+			{Label: "replicas", Properties: fields.FieldProperties{Display: "Replicas", Type: "number", DefaultValue: "3", Precision: &precision}},
+			{Label: "regions", Properties: fields.FieldProperties{Display: "Regions", Type: "multiselect", DefaultValue: "eu-west-1", Choices: []fields.Choice{
+				{Label: "eu-west-1", Value: "eu-west-1"},
+				{Label: "us-east-1", Value: "us-east-1"},
+			}}},
+			{Label: "notes", Properties: fields.FieldProperties{Type: "textarea"}},
+			{Label: "upload", Properties: fields.FieldProperties{Display: "Upload", Type: "file"}},
+			{Label: "intro", Properties: fields.FieldProperties{Display: "Intro", Type: "markdown", Content: "Intro"}},
+		},
+	}
+
+	form := url.Values{
+		"name":     {"bob"},
+		"greeting": {"hello bob"},
+		"token":    {"s3cr3t"},
+		"replicas": {"3"},
+		"regions":  {"eu-west-1", "us-east-1"},
+		"notes":    {"line one\nline two"},
+		"upload":   {"plan.txt"},
+		"intro":    {"ignored"},
+	}
+
+	items, err := f.Review(form, fields.TemplateData{})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []fields.ReviewItem{
+		{Label: "name", Display: "Name", Value: "bob", Changed: true},
+		{Label: "greeting", Display: "Greeting", Value: "hello bob", Changed: false},
+		{Label: "token", Display: "Token", Value: fields.MaskedValue, Secret: true, Changed: true},
+		{Label: "replicas", Display: "Replicas", Value: "3.00", Changed: false},
+		{Label: "regions", Display: "Regions", Value: "eu-west-1,us-east-1", Changed: true},
+		{Label: "notes", Display: "notes", Value: "line one\nline two", Changed: true},
+		{Label: "upload", Display: "Upload", Uploaded: true},
+	}, items)
+}
@@ -0,0 +1,208 @@
+package fields
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/boasihq/interactive-inputs/internal/errors"
+	"github.com/boasihq/interactive-inputs/internal/toolbox"
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/ast"
+	"github.com/expr-lang/expr/parser"
+	"github.com/expr-lang/expr/vm"
+	"github.com/sethvargo/go-githubactions"
+)
+
+// ruleFieldsVariable is the name of the variable that holds the values of the fields,
+// keyed by field label, within a rule's expression
+const ruleFieldsVariable string = "fields"
+
+// Rule represents a constraint that spans multiple fields, such as an end date that
+// must come after a start date. Rules are checked once the values of the fields they
+// reference are individually valid.
+// Expression is an expression (https://expr-lang.org) that must evaluate to true for
+// the submitted values to be valid. Field values are referenced by label through the
+// fields variable, i.e. fields["max-replicas"] >= fields["min-replicas"].
+// Message is the message shown to the user when the rule is not satisfied.
+// Fields are the labels of the fields the message is shown against. If not provided,
+// the fields referenced by the expression are used.
+type Rule struct {
+	Expression string   `yaml:"expression"`
+	Message    string   `yaml:"message"`
+	Fields     []string `yaml:"fields"`
+
+	// references are the labels of the fields referenced by the expression
+	references []string
+
+	// program is the compiled expression
+	program *vm.Program
+}
+
+// referenceVisitor collects the field labels referenced by a rule's expression
+type referenceVisitor struct {
+	references []string
+}
+
+// Visit records member accesses on the fields variable, i.e. fields["label"] or fields.label
+func (v *referenceVisitor) Visit(node *ast.Node) {
+	member, ok := (*node).(*ast.MemberNode)
+	if !ok {
+		return
+	}
+
+	identifier, ok := member.Node.(*ast.IdentifierNode)
+	if !ok || identifier.Value != ruleFieldsVariable {
+		return
+	}
+
+	property, ok := member.Property.(*ast.StringNode)
+	if ok && !toolbox.StringInSlice(property.Value, v.references) {
+		v.references = append(v.references, property.Value)
+	}
+}
+
+// resolveRules compiles the rules' expressions and makes sure the rules only reference
+// fields that exist in the form.
+func resolveRules(fields *Fields, action *githubactions.Action) error {
+	var fieldLabels []string = make([]string, 0, len(fields.Fields))
+	for _, field := range fields.Fields {
+		fieldLabels = append(fieldLabels, field.Label)
+	}
+
+	for i, rule := range fields.Rules {
+		if strings.TrimSpace(rule.Expression) == "" {
+			action.Errorf("No expression provided for rule %d", i+1)
+			return errors.ErrInvalidRuleProvided
+		}
+
+		tree, err := parser.Parse(rule.Expression)
+		if err != nil {
+			action.Errorf("Invalid expression provided for rule %d: %s", i+1, err)
+			return errors.ErrInvalidRuleProvided
+		}
+
+		visitor := &referenceVisitor{}
+		ast.Walk(&tree.Node, visitor)
+
+		for _, label := range visitor.references {
+			if !toolbox.StringInSlice(label, fieldLabels) {
+				action.Errorf("Rule %d references an unknown field: '%s'", i+1, label)
+				return errors.ErrInvalidRuleProvided
+			}
+		}
+
+		program, err := expr.Compile(rule.Expression, expr.Env(map[string]interface{}{ruleFieldsVariable: map[string]interface{}{}}), expr.AsBool())
+		if err != nil {
+			action.Errorf("Invalid expression provided for rule %d: %s", i+1, err)
+			return errors.ErrInvalidRuleProvided
+		}
+
+		// make sure the labels of the fields to show the message against are
+		// standardised in the same way as the field labels
+		for j, label := range rule.Fields {
+			labelKebabCase, err := toolbox.StringConvertToKebabCase(
+				toolbox.StringRemoveSpecialCharactersWith(label, ""),
+			)
+			if err != nil || !toolbox.StringInSlice(labelKebabCase, fieldLabels) {
+				action.Errorf("Rule %d references an unknown field: '%s'", i+1, label)
+				return errors.ErrInvalidRuleProvided
+			}
+			fields.Rules[i].Fields[j] = labelKebabCase
+		}
+
+		if len(rule.Fields) == 0 {
+			if len(visitor.references) == 0 {
+				action.Errorf("Rule %d must reference at least one field", i+1)
+				return errors.ErrInvalidRuleProvided
+			}
+			fields.Rules[i].Fields = visitor.references
+		}
+
+		if rule.Message == "" {
+			fields.Rules[i].Message = fmt.Sprintf("Must satisfy the rule: %s", rule.Expression)
+		}
+
+		fields.Rules[i].references = visitor.references
+		fields.Rules[i].program = program
+	}
+
+	return nil
+}
+
+// validateRules checks the rules relevant to the given step against the submitted form
+// values, adding the message of each unsatisfied rule to the fields it is shown against.
+// Rules referencing a field that already has an error are skipped, as are rules
+// referencing fields on later steps.
+func (f *Fields) validateRules(form url.Values, stepLabel string, fieldErrors FieldErrors) {
+	if len(f.Rules) == 0 {
+		return
+	}
+
+	var environment map[string]interface{} = map[string]interface{}{
+		ruleFieldsVariable: f.ruleValues(form),
+	}
+
+	for _, rule := range f.Rules {
+		if !f.ruleIsRelevant(rule, stepLabel) || hasFieldErrors(rule, fieldErrors) {
+			continue
+		}
+
+		result, err := expr.Run(rule.program, environment)
+		if satisfied, ok := result.(bool); err == nil && ok && satisfied {
+			continue
+		}
+
+		for _, label := range rule.Fields {
+			if _, ok := fieldErrors[label]; !ok {
+				fieldErrors[label] = rule.Message
+			}
+		}
+	}
+}
+
+// ruleIsRelevant returns whether the rule should be checked when validating the given
+// step, which is the case when the last step any of its fields are on is the given step.
+func (f *Fields) ruleIsRelevant(rule Rule, stepLabel string) bool {
+	if stepLabel == "" {
+		return true
+	}
+
+	var lastStepIndex int = -1
+	for _, field := range f.Fields {
+		if !toolbox.StringInSlice(field.Label, rule.references) && !toolbox.StringInSlice(field.Label, rule.Fields) {
+			continue
+		}
+
+		if stepIndex := f.StepIndex(field.Properties.Step); stepIndex > lastStepIndex {
+			lastStepIndex = stepIndex
+		}
+	}
+
+	return lastStepIndex == f.StepIndex(stepLabel)
+}
+
+// hasFieldErrors returns whether any of the fields used by the rule have an error
+func hasFieldErrors(rule Rule, fieldErrors FieldErrors) bool {
+	for label := range fieldErrors {
+		fieldLabel, _, _ := strings.Cut(label, groupInputNameSeparator)
+		if toolbox.StringInSlice(fieldLabel, rule.references) || toolbox.StringInSlice(fieldLabel, rule.Fields) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ruleValues returns the submitted values of the fields, keyed by field label, converted
+// to the types that best represent each field so they can be compared in expressions.
+// Fields without a value are nil.
+func (f *Fields) ruleValues(form url.Values) map[string]interface{} {
+	var values map[string]interface{} = make(map[string]interface{}, len(f.Fields))
+
+	for _, field := range f.Fields {
+		values[field.Label] = field.TypedValue(form)
+	}
+
+	return values
+}
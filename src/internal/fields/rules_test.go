@@ -0,0 +1,173 @@
+package fields_test
+
+import (
+	"bytes"
+	"net/url"
+	"testing"
+
+	"github.com/boasihq/interactive-inputs/internal/fields"
+	"github.com/sethvargo/go-githubactions"
+	"github.com/stretchr/testify/assert"
+)
+
+const rulesFieldsString string = `
+steps:
+  - label: schedule
+  - label: scaling
+fields:
+  - label: start-date
+    properties:
+      type: text
+      step: schedule
+  - label: end-date
+    properties:
+      type: text
+      step: schedule
+  - label: min-replicas
+    properties:
+      type: number
+      step: scaling
+  - label: max-replicas
+    properties:
+      type: number
+      step: scaling
+  - label: notify-slack
+    properties:
+      type: boolean
+      step: scaling
+  - label: notify-email
+    properties:
+      type: boolean
+      step: scaling
+rules:
+  - expression: date(fields["end-date"]) > date(fields["start-date"])
+    message: The end date must be after the start date
+    fields: [End Date]
+  - expression: fields["max-replicas"] >= fields["min-replicas"]
+    message: Max replicas must be at least min replicas
+  - expression: fields["notify-slack"] == true || fields["notify-email"] == true
+    message: At least one notification channel must be selected
+    fields: [notify-slack]
+`
+
+func TestMarshalStringIntoValidFieldsStruct_Rules(t *testing.T) {
+	tests := []struct {
+		name           string
+		fieldsString   string
+		expectedError  bool
+		expectedFields [][]string
+		expectedOutput string
+	}{
+		{
+			name:           "success - rule fields standardised and derived from the expression",
+			fieldsString:   rulesFieldsString,
+			expectedError:  false,
+			expectedFields: [][]string{{"end-date"}, {"max-replicas", "min-replicas"}, {"notify-slack"}},
+		},
+		{
+			name:           "failed - expression references an unknown field",
+			fieldsString:   "fields:\n  - label: name\n    properties:\n      type: text\nrules:\n  - expression: fields.nme != ''\n",
+			expectedError:  true,
+			expectedOutput: "::error::Rule 1 references an unknown field: 'nme'\n",
+		},
+		{
+			name:           "failed - message shown against an unknown field",
+			fieldsString:   "fields:\n  - label: name\n    properties:\n      type: text\nrules:\n  - expression: fields.name != ''\n    fields: [other]\n",
+			expectedError:  true,
+			expectedOutput: "::error::Rule 1 references an unknown field: 'other'\n",
+		},
+		{
+			name:           "failed - expression can't be parsed",
+			fieldsString:   "fields:\n  - label: name\n    properties:\n      type: text\nrules:\n  - expression: fields.name !=\n",
+			expectedError:  true,
+			expectedOutput: "::error::Invalid expression provided for rule 1: unexpected token EOF (1:14)%0A | fields.name !=%0A | .............^\n",
+		},
+		{
+			name:           "failed - expression does not return a boolean",
+			fieldsString:   "fields:\n  - label: name\n    properties:\n      type: text\nrules:\n  - expression: len(fields.name)\n",
+			expectedError:  true,
+			expectedOutput: "::error::Invalid expression provided for rule 1: expected bool, but got int\n",
+		},
+		{
+			name:           "failed - rule without field references",
+			fieldsString:   "fields:\n  - label: name\n    properties:\n      type: text\nrules:\n  - expression: 1 < 2\n",
+			expectedError:  true,
+			expectedOutput: "::error::Rule 1 must reference at least one field\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actionLog := bytes.NewBuffer(nil)
+			action := githubactions.New(githubactions.WithWriter(actionLog))
+
+			result, err := fields.MarshalStringIntoValidFieldsStruct(tt.fieldsString, action)
+
+			assert.Equal(t, tt.expectedOutput, actionLog.String())
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+
+			var ruleFields [][]string
+			for _, rule := range result.Rules {
+				ruleFields = append(ruleFields, rule.Fields)
+			}
+			assert.Equal(t, tt.expectedFields, ruleFields)
+		})
+	}
+}
+
+func TestFields_ValidateRules(t *testing.T) {
+	action := githubactions.New(githubactions.WithWriter(bytes.NewBuffer(nil)))
+	f, err := fields.MarshalStringIntoValidFieldsStruct(rulesFieldsString, action)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		form           url.Values
+		step           string
+		expectedErrors fields.FieldErrors
+	}{
+		{
+			name:           "all rules satisfied",
+			form:           url.Values{"start-date": {"2026-01-01"}, "end-date": {"2026-02-01"}, "min-replicas": {"2"}, "max-replicas": {"10"}, "notify-email": {"true"}},
+			expectedErrors: fields.FieldErrors{},
+		},
+		{
+			name: "all rules unsatisfied",
+			form: url.Values{"start-date": {"2026-02-01"}, "end-date": {"2026-01-01"}, "min-replicas": {"5"}, "max-replicas": {"3"}, "notify-slack": {"false"}},
+			expectedErrors: fields.FieldErrors{
+				"end-date":     "The end date must be after the start date",
+				"min-replicas": "Max replicas must be at least min replicas",
+				"max-replicas": "Max replicas must be at least min replicas",
+				"notify-slack": "At least one notification channel must be selected",
+			},
+		},
+		{
+			name:           "only rules ending on the given step are checked",
+			form:           url.Values{"start-date": {"2026-02-01"}, "end-date": {"2026-01-01"}, "min-replicas": {"5"}, "max-replicas": {"3"}},
+			step:           "schedule",
+			expectedErrors: fields.FieldErrors{"end-date": "The end date must be after the start date"},
+		},
+		{
+			name:           "rules with invalid field values are skipped",
+			form:           url.Values{"start-date": {"2026-01-01"}, "end-date": {"2026-02-01"}, "min-replicas": {"five"}, "max-replicas": {"3"}, "notify-slack": {"true"}},
+			expectedErrors: fields.FieldErrors{"min-replicas": "Must be a number"},
+		},
+		{
+			name:           "rules that fail to evaluate are unsatisfied",
+			form:           url.Values{"start-date": {"soon"}, "end-date": {"2026-02-01"}, "min-replicas": {"1"}, "max-replicas": {"3"}, "notify-slack": {"true"}},
+			expectedErrors: fields.FieldErrors{"end-date": "The end date must be after the start date"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedErrors, f.Validate(tt.form, tt.step, fields.TemplateData{}))
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package fields
+
+import (
+	"github.com/boasihq/interactive-inputs/internal/errors"
+	"github.com/boasihq/interactive-inputs/internal/toolbox"
+	"github.com/sethvargo/go-githubactions"
+)
+
+// Step represents an ordered page of a multi-step form. Fields are assigned to a step
+// using their step property, and the user can only move on to the next step once the
+// values entered for the current step are valid.
+// Label is the unique identifier of the step, referenced by the fields' step property.
+// Display is the title shown to the user for the step.
+// Description is an optional description of the step to show the user.
+type Step struct {
+	Label       string `yaml:"label"`
+	Display     string `yaml:"display"`
+	Description string `yaml:"description"`
+}
+
+// Section is a named group of consecutive fields that are displayed together
+type Section struct {
+	Name   string
+	Fields []Field
+}
+
+// StepLayout holds the sections of fields that are displayed on a given step of the form
+type StepLayout struct {
+	Step     Step
+	Sections []Section
+}
+
+// HasSteps returns whether the fields should be displayed as a multi-step form
+func (f *Fields) HasSteps() bool {
+	return len(f.Steps) > 0
+}
+
+// StepIndex returns the position of the step with the given label, or -1 if no
+// such step exists.
+func (f *Fields) StepIndex(stepLabel string) int {
+	for i, step := range f.Steps {
+		if step.Label == stepLabel {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Layout returns the fields grouped into the steps and sections they should be
+// displayed in. When no steps are defined, a single unnamed step holding all
+// of the fields is returned.
+func (f *Fields) Layout() []StepLayout {
+	var steps []Step = f.Steps
+	if !f.HasSteps() {
+		steps = []Step{{}}
+	}
+
+	layout := make([]StepLayout, 0, len(steps))
+	for _, step := range steps {
+		stepLayout := StepLayout{Step: step}
+
+		for _, field := range f.Fields {
+			if field.Properties.Step != step.Label {
+				continue
+			}
+
+			// consecutive fields sharing a section name are displayed together
+			lastSection := len(stepLayout.Sections) - 1
+			if lastSection < 0 || stepLayout.Sections[lastSection].Name != field.Properties.Section {
+				stepLayout.Sections = append(stepLayout.Sections, Section{Name: field.Properties.Section})
+				lastSection++
+			}
+
+			stepLayout.Sections[lastSection].Fields = append(stepLayout.Sections[lastSection].Fields, field)
+		}
+
+		layout = append(layout, stepLayout)
+	}
+
+	return layout
+}
+
+// resolveSteps validates the steps defined for the form and makes sure each field
+// references a valid step. If no steps are defined but fields reference them, the
+// steps are derived from the fields in the order they are first referenced. Fields
+// without a step are placed in the first step.
+func resolveSteps(fields *Fields, action *githubactions.Action) error {
+	var detectedStepLabels []string = make([]string, 0, len(fields.Steps))
+
+	for i, step := range fields.Steps {
+		stepLabel, err := toolbox.StringConvertToKebabCase(
+			toolbox.StringRemoveSpecialCharactersWith(step.Label, ""),
+		)
+		if err != nil || stepLabel == "" {
+			action.Errorf("Invalid step label provided - '%s' is not kebab case compatible", step.Label)
+			return errors.ErrInvalidLabelProvided
+		}
+
+		if toolbox.StringInSlice(stepLabel, detectedStepLabels) {
+			action.Errorf("Duplicate step label detected: '%s'", stepLabel)
+			return errors.ErrDuplicateStepLabelDetected
+		}
+
+		fields.Steps[i].Label = stepLabel
+		if step.Display == "" {
+			fields.Steps[i].Display = step.Label
+		}
+		detectedStepLabels = append(detectedStepLabels, stepLabel)
+	}
+
+	var deriveSteps bool = len(fields.Steps) == 0
+
+	for i, field := range fields.Fields {
+		if field.Properties.Step == "" {
+			continue
+		}
+
+		stepLabel, err := toolbox.StringConvertToKebabCase(
+			toolbox.StringRemoveSpecialCharactersWith(field.Properties.Step, ""),
+		)
+		if err != nil || stepLabel == "" {
+			action.Errorf("Invalid step provided for field '%s' - '%s' is not kebab case compatible", field.Label, field.Properties.Step)
+			return errors.ErrInvalidStepReferenceProvided
+		}
+
+		if !toolbox.StringInSlice(stepLabel, detectedStepLabels) {
+			if !deriveSteps {
+				action.Errorf("Field '%s' references an undefined step: '%s'", field.Label, field.Properties.Step)
+				return errors.ErrInvalidStepReferenceProvided
+			}
+
+			fields.Steps = append(fields.Steps, Step{Label: stepLabel, Display: field.Properties.Step})
+			detectedStepLabels = append(detectedStepLabels, stepLabel)
+		}
+
+		fields.Fields[i].Properties.Step = stepLabel
+	}
+
+	if !fields.HasSteps() {
+		return nil
+	}
+
+	for i, field := range fields.Fields {
+		if field.Properties.Step == "" {
+			fields.Fields[i].Properties.Step = fields.Steps[0].Label
+		}
+	}
+
+	return nil
+}
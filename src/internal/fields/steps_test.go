@@ -0,0 +1,123 @@
+package fields_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/boasihq/interactive-inputs/internal/fields"
+	"github.com/sethvargo/go-githubactions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalStringIntoValidFieldsStruct_Steps(t *testing.T) {
+	tests := []struct {
+		name           string
+		fieldsString   string
+		expectedError  bool
+		expectedSteps  []fields.Step
+		expectedFields map[string]string
+		expectedOutput string
+	}{
+		{
+			name:          "success - defined steps with fields defaulting to the first step",
+			fieldsString:  "steps:\n  - label: Target\n    display: Choose a target\n  - label: details\nfields:\n  - label: environment\n    properties:\n      type: text\n  - label: notes\n    properties:\n      type: textarea\n      step: details\n",
+			expectedError: false,
+			expectedSteps: []fields.Step{
+				{Label: "target", Display: "Choose a target"},
+				{Label: "details", Display: "details"},
+			},
+			expectedFields: map[string]string{"environment": "target", "notes": "details"},
+		},
+		{
+			name:          "success - steps derived from fields in order of reference",
+			fieldsString:  "fields:\n  - label: environment\n    properties:\n      type: text\n      step: Target Environment\n  - label: notes\n    properties:\n      type: textarea\n      step: Details\n  - label: version\n    properties:\n      type: text\n      step: Target Environment\n",
+			expectedError: false,
+			expectedSteps: []fields.Step{
+				{Label: "target-environment", Display: "Target Environment"},
+				{Label: "details", Display: "Details"},
+			},
+			expectedFields: map[string]string{"environment": "target-environment", "notes": "details", "version": "target-environment"},
+		},
+		{
+			name:           "failed - field references an undefined step",
+			fieldsString:   "steps:\n  - label: target\nfields:\n  - label: environment\n    properties:\n      type: text\n      step: other\n",
+			expectedError:  true,
+			expectedOutput: "::error::Field 'environment' references an undefined step: 'other'\n",
+		},
+		{
+			name:           "failed - duplicate step labels",
+			fieldsString:   "steps:\n  - label: target\n  - label: Target\nfields:\n  - label: environment\n    properties:\n      type: text\n",
+			expectedError:  true,
+			expectedOutput: "::error::Duplicate step label detected: 'target'\n",
+		},
+		{
+			name:           "failed - invalid default value template",
+			fieldsString:   "fields:\n  - label: environment\n    properties:\n      type: text\n      defaultValue: '{{ .Answers.version'\n",
+			expectedError:  true,
+			expectedOutput: "::error::Invalid default value template provided for field 'environment': template: property:1: unclosed action\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actionLog := bytes.NewBuffer(nil)
+			action := githubactions.New(githubactions.WithWriter(actionLog))
+
+			result, err := fields.MarshalStringIntoValidFieldsStruct(tt.fieldsString, action)
+
+			assert.Equal(t, tt.expectedOutput, actionLog.String())
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedSteps, result.Steps)
+			for _, field := range result.Fields {
+				assert.Equal(t, tt.expectedFields[field.Label], field.Properties.Step)
+			}
+		})
+	}
+}
+
+func TestFields_Layout(t *testing.T) {
+	f := &fields.Fields{
+		Steps: []fields.Step{{Label: "target"}, {Label: "details"}},
+		Fields: []fields.Field{
+			{Label: "environment", Properties: fields.FieldProperties{Step: "target", Section: "Where"}},
+			{Label: "notes", Properties: fields.FieldProperties{Step: "details"}},
+			{Label: "region", Properties: fields.FieldProperties{Step: "target", Section: "Where"}},
+			{Label: "version", Properties: fields.FieldProperties{Step: "target", Section: "What"}},
+		},
+	}
+
+	layout := f.Layout()
+
+	assert.Len(t, layout, 2)
+	assert.Equal(t, "target", layout[0].Step.Label)
+	assert.Equal(t, []fields.Section{
+		{Name: "Where", Fields: []fields.Field{f.Fields[0], f.Fields[2]}},
+		{Name: "What", Fields: []fields.Field{f.Fields[3]}},
+	}, layout[0].Sections)
+	assert.Equal(t, []fields.Section{{Name: "", Fields: []fields.Field{f.Fields[1]}}}, layout[1].Sections)
+}
+
+func TestFields_TemplatedDefaults(t *testing.T) {
+	f := &fields.Fields{
+		Steps: []fields.Step{{Label: "target"}, {Label: "details"}},
+		Fields: []fields.Field{
+			{Label: "environment", Properties: fields.FieldProperties{Step: "target"}},
+			{Label: "target-version", Properties: fields.FieldProperties{Step: "target"}},
+			{Label: "notes", Properties: fields.FieldProperties{Step: "details", DefaultValue: `Deploying {{ answer "target-version" }} to {{ .Answers.environment }}`}},
+			{Label: "owner", Properties: fields.FieldProperties{Step: "details", DefaultValue: "platform"}},
+		},
+	}
+
+	defaults, err := f.TemplatedDefaults("details", fields.TemplateData{
+		Answers: map[string]string{"environment": "production", "target-version": "v1.2.0"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"notes": "Deploying v1.2.0 to production"}, defaults)
+}
@@ -0,0 +1,205 @@
+package fields
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/boasihq/interactive-inputs/internal/toolbox"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+
+	// inputProperties is a list of the properties supported by every field type that
+	// captures a value. Display-only fields don't support them.
+	inputProperties = []string{
+		"required",
+		"defaultValue",
+		"readOnly",
+		"balloonValues",
+		"balloonValueEnvKeys",
+		"outputFromEnvKey",
+		"outputTitle",
+	}
+)
+
+// checkStrictly walks the YAML document of the fields config, returning a description of
+// every key that isn't known, with a suggestion when it looks like a typo of a known key,
+// and of every property provided for a field type that doesn't support it. The line and
+// column of each problem is included so it can be found quickly.
+func checkStrictly(fieldsString string) []string {
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(fieldsString), &document); err != nil || len(document.Content) == 0 {
+		// syntax errors are reported when the fields are unmarshalled
+		return nil
+	}
+
+	var problems []string
+	root := document.Content[0]
+	checkKeys(root, reflect.TypeOf(Fields{}), "the fields config", &problems)
+
+	for _, key := range []string{"fields", "steps", "rules"} {
+		list := toolbox.YamlMappingValue(root, key)
+		if list == nil || list.Kind != yaml.SequenceNode {
+			continue
+		}
+
+		for i, item := range list.Content {
+			switch key {
+			case "fields":
+				checkField(item, fmt.Sprintf("field %d", i+1), &problems)
+			case "steps":
+				checkKeys(item, reflect.TypeOf(Step{}), fmt.Sprintf("step %d", i+1), &problems)
+			case "rules":
+				checkKeys(item, reflect.TypeOf(Rule{}), fmt.Sprintf("rule %d", i+1), &problems)
+			}
+		}
+	}
+
+	return problems
+}
+
+// checkField checks the keys of a field and its properties, including the sub-fields of
+// a group field
+func checkField(node *yaml.Node, fallbackName string, problems *[]string) {
+	name := fallbackName
+	if label := toolbox.YamlMappingValue(node, "label"); label != nil && label.Value != "" {
+		name = fmt.Sprintf("field '%s'", label.Value)
+	}
+
+	checkKeys(node, reflect.TypeOf(Field{}), name, problems)
+
+	properties := toolbox.YamlMappingValue(node, "properties")
+	if properties == nil || properties.Kind != yaml.MappingNode {
+		return
+	}
+
+	checkKeys(properties, reflect.TypeOf(FieldProperties{}), name, problems)
+
+	var fieldType string
+	if typeNode := toolbox.YamlMappingValue(properties, "type"); typeNode != nil {
+		fieldType = toolbox.StringStandardisedToLower(typeNode.Value)
+	}
+
+	// unsupported types are reported when the field is normalised
+	if registeredType, ok := LookupFieldType(fieldType); ok {
+		isDisplayOnly := registeredType.DisplayOnly
+
+		for i := 0; i+1 < len(properties.Content); i += 2 {
+			key := properties.Content[i]
+
+			supportedTypes, isTypeSpecific := typesSupportingProperty(key.Value)
+			switch {
+			case isTypeSpecific && !toolbox.StringInSlice(fieldType, supportedTypes):
+				*problems = append(*problems, fmt.Sprintf(
+					"Property '%s' isn't supported by the %s type of %s (line %d, column %d) - it is only supported by: %s",
+					key.Value, fieldType, name, key.Line, key.Column, strings.Join(supportedTypes, ", "),
+				))
+
+			case isDisplayOnly && toolbox.StringInSlice(key.Value, inputProperties):
+				*problems = append(*problems, fmt.Sprintf(
+					"Property '%s' isn't supported by the %s type of %s (line %d, column %d) - it doesn't capture a value",
+					key.Value, fieldType, name, key.Line, key.Column,
+				))
+			}
+		}
+	}
+
+	choices := toolbox.YamlMappingValue(properties, "choices")
+	if choices != nil && choices.Kind == yaml.SequenceNode {
+		for i, choice := range choices.Content {
+			// choices can also be plain strings
+			if choice.Kind == yaml.MappingNode {
+				checkKeys(choice, reflect.TypeOf(Choice{}), fmt.Sprintf("choice %d of %s", i+1, name), problems)
+			}
+		}
+	}
+
+	subFields := toolbox.YamlMappingValue(properties, "fields")
+	if subFields != nil && subFields.Kind == yaml.SequenceNode {
+		for i, subField := range subFields.Content {
+			checkField(subField, fmt.Sprintf("sub-field %d of %s", i+1, name), problems)
+		}
+	}
+}
+
+// checkKeys reports the keys of the mapping node that don't match the yaml tag of one
+// of the fields of the given struct type
+func checkKeys(node *yaml.Node, structType reflect.Type, name string, problems *[]string) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+
+	knownKeys := yamlKeys(structType)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i]
+		if toolbox.StringInSlice(key.Value, knownKeys) {
+			continue
+		}
+
+		problem := fmt.Sprintf("Unknown property '%s' provided for %s (line %d, column %d)", key.Value, name, key.Line, key.Column)
+		if suggestion := closestKey(key.Value, knownKeys); suggestion != "" {
+			problem = fmt.Sprintf("%s - did you mean '%s'?", problem, suggestion)
+		}
+		*problems = append(*problems, problem)
+	}
+}
+
+// yamlKeys returns the keys of the exported fields of the struct type, as named by their yaml tags
+func yamlKeys(structType reflect.Type) []string {
+	var keys []string
+	for i := 0; i < structType.NumField(); i++ {
+		structField := structType.Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+
+		key, _, _ := strings.Cut(structField.Tag.Get("yaml"), ",")
+		if key == "" || key == "-" {
+			continue
+		}
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// closestKey returns the known key most similar to the given key, ignoring case, or an
+// empty string if none are similar enough to be a likely typo
+func closestKey(key string, knownKeys []string) string {
+	var closest string
+	var closestDistance int = len(key)/3 + 2
+
+	for _, knownKey := range knownKeys {
+		distance := editDistance(strings.ToLower(key), strings.ToLower(knownKey))
+		if distance < closestDistance {
+			closest, closestDistance = knownKey, distance
+		}
+	}
+
+	return closest
+}
+
+// editDistance returns the Levenshtein distance between the two strings
+func editDistance(a string, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
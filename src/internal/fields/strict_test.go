@@ -0,0 +1,95 @@
+package fields_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/boasihq/interactive-inputs/internal/errors"
+	"github.com/boasihq/interactive-inputs/internal/fields"
+	"github.com/sethvargo/go-githubactions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalStringIntoValidFieldsStruct_Strict(t *testing.T) {
+	validFieldTypes := strings.Join(fields.FieldTypeNames(nil), ", ")
+
+	tests := []struct {
+		name           string
+		fieldsString   string
+		expectedError  error
+		expectedOutput string
+	}{
+		{
+			name:          "success - known properties",
+			fieldsString:  "fields:\n  - label: name\n    properties:\n      type: text\n      required: true\n      maxLength: 10\n",
+			expectedError: nil,
+		},
+		{
+			name:           "failed - misspelt property with a suggestion",
+			fieldsString:   "fields:\n  - label: name\n    properties:\n      type: text\n      requried: true\n",
+			expectedError:  errors.ErrInvalidPropertyProvided,
+			expectedOutput: "::error::Unknown property 'requried' provided for field 'name' (line 5, column 7) - did you mean 'required'?\n",
+		},
+		{
+			name:           "failed - wrongly cased property",
+			fieldsString:   "fields:\n  - label: name\n    properties:\n      type: text\n      maxlength: 10\n",
+			expectedError:  errors.ErrInvalidPropertyProvided,
+			expectedOutput: "::error::Unknown property 'maxlength' provided for field 'name' (line 5, column 7) - did you mean 'maxLength'?\n",
+		},
+		{
+			name:           "failed - unknown property without a suggestion",
+			fieldsString:   "fields:\n  - label: name\n    properties:\n      type: text\n      colour: red\n",
+			expectedError:  errors.ErrInvalidPropertyProvided,
+			expectedOutput: "::error::Unknown property 'colour' provided for field 'name' (line 5, column 7)\n",
+		},
+		{
+			name:           "failed - property not supported by the field type",
+			fieldsString:   "fields:\n  - label: name\n    properties:\n      type: text\n      choices: [a, b]\n",
+			expectedError:  errors.ErrInvalidPropertyProvided,
+			expectedOutput: "::error::Property 'choices' isn't supported by the text type of field 'name' (line 5, column 7) - it is only supported by: select, multiselect, region\n",
+		},
+		{
+			name:           "failed - input property on a display-only field",
+			fieldsString:   "fields:\n  - label: notes\n    properties:\n      type: markdown\n      content: Hello\n      required: true\n",
+			expectedError:  errors.ErrInvalidPropertyProvided,
+			expectedOutput: "::error::Property 'required' isn't supported by the markdown type of field 'notes' (line 6, column 7) - it doesn't capture a value\n",
+		},
+		{
+			name:           "failed - misspelt choice property",
+			fieldsString:   "fields:\n  - label: env\n    properties:\n      type: select\n      choices:\n        - lable: Production\n          value: prod\n",
+			expectedError:  errors.ErrInvalidPropertyProvided,
+			expectedOutput: "::error::Unknown property 'lable' provided for choice 1 of field 'env' (line 6, column 11) - did you mean 'label'?\n",
+		},
+		{
+			name:           "failed - misspelt field key",
+			fieldsString:   "fields:\n  - label: name\n    propertes:\n      type: text\n",
+			expectedError:  errors.ErrInvalidPropertyProvided,
+			expectedOutput: "::error::Unknown property 'propertes' provided for field 'name' (line 3, column 5) - did you mean 'properties'?\n::error::Invalid field type '' provided for field 'name'. Valid field types are: " + validFieldTypes + "\n",
+		},
+		{
+			name:           "failed - misspelt top-level key",
+			fieldsString:   "fields:\n  - label: name\n    properties:\n      type: text\nstep:\n  - title: one\n",
+			expectedError:  errors.ErrInvalidPropertyProvided,
+			expectedOutput: "::error::Unknown property 'step' provided for the fields config (line 5, column 1) - did you mean 'steps'?\n",
+		},
+		{
+			name:           "failed - several problems are all reported",
+			fieldsString:   "fields:\n  - label: name\n    properties:\n      type: text\n      requried: true\n  - label: age\n    properties:\n      type: number\n      maxLength: 3\n  - label: colour\n    properties:\n      type: rainbow\n",
+			expectedError:  errors.ErrInvalidPropertyProvided,
+			expectedOutput: "::error::Unknown property 'requried' provided for field 'name' (line 5, column 7) - did you mean 'required'?\n::error::Property 'maxLength' isn't supported by the number type of field 'age' (line 9, column 7) - it is only supported by: text, textarea\n::error::Invalid field type 'rainbow' provided for field 'colour'. Valid field types are: " + validFieldTypes + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actionLog := bytes.NewBuffer(nil)
+			action := githubactions.New(githubactions.WithWriter(actionLog))
+
+			_, err := fields.MarshalStringIntoValidFieldsStruct(tt.fieldsString, action)
+
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expectedOutput, actionLog.String())
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package fields
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/boasihq/interactive-inputs/internal/errors"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/sethvargo/go-githubactions"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+
+	// jsonFieldType is a JSON document
+	jsonFieldType = structuredFieldType("json")
+
+	// yamlFieldType is a YAML document
+	yamlFieldType = structuredFieldType("yaml")
+)
+
+// structuredFieldType returns the field type of a structured document in the given format.
+// The documents are optionally validated against a JSON Schema, and output as normalised JSON.
+func structuredFieldType(name string) FieldType {
+	return FieldType{
+		Name:       name,
+		Properties: []string{"placeholder", "schema", "schemaFile", "editableBy"},
+		Template:   "field-structured",
+		Normalise:  normaliseStructuredField,
+		Validate: validateSubmittedValues(func(field Field, values []string) string {
+			return field.validateStructuredValue(values[0])
+		}),
+		Output: encodeSubmittedValues(Field.structuredOutput),
+		TypedValue: func(field Field, form url.Values) interface{} {
+			var document interface{}
+			if value := strings.TrimSpace(form.Get(field.Label)); value != "" {
+				if output, err := field.structuredOutput(value); err == nil {
+					_ = json.Unmarshal([]byte(output), &document)
+				}
+			}
+			return document
+		},
+	}
+}
+
+// structuredSchemaLocation is the location the schema of a structured field is
+// registered under when it is compiled
+const structuredSchemaLocation string = "schema.json"
+
+// normaliseStructuredField loads and compiles the JSON Schema of a structured field,
+// and makes sure its default value is a valid document. JSON default values are
+// pretty-printed so they are easier to edit in the portal.
+func normaliseStructuredField(field *Field, action *githubactions.Action) error {
+	if field.Properties.Schema != "" && field.Properties.SchemaFile != "" {
+		action.Errorf("Invalid schema provided for field '%s' - only one of schema or schemaFile can be provided", field.Label)
+		return errors.ErrInvalidSchemaProvided
+	}
+
+	var schemaText string = field.Properties.Schema
+	if field.Properties.SchemaFile != "" {
+		schemaBytes, err := os.ReadFile(WorkspacePath(action, field.Properties.SchemaFile))
+		if err != nil {
+			action.Errorf("Unable to read the schema file provided for field '%s': %s", field.Label, err)
+			return errors.ErrInvalidSchemaProvided
+		}
+		schemaText = string(schemaBytes)
+	}
+
+	if schemaText != "" {
+		schema, err := compileSchema(schemaText)
+		if err != nil {
+			action.Errorf("Invalid schema provided for field '%s': %s", field.Label, err)
+			return errors.ErrInvalidSchemaProvided
+		}
+		field.Properties.schema = schema
+	}
+
+	if field.Properties.DefaultValue == "" || IsTemplated(field.Properties.DefaultValue) {
+		return nil
+	}
+
+	if message := field.validateStructuredValue(field.Properties.DefaultValue); message != "" {
+		action.Errorf("Invalid default value provided for field '%s': %s", field.Label, message)
+		return errors.ErrInvalidDefaultValueProvided
+	}
+
+	if field.Properties.Type == "json" {
+		var formatted bytes.Buffer
+		if err := json.Indent(&formatted, []byte(strings.TrimSpace(field.Properties.DefaultValue)), "", "  "); err == nil {
+			field.Properties.DefaultValue = formatted.String()
+		}
+	}
+
+	return nil
+}
+
+// compileSchema compiles the given JSON Schema, which can be written in either JSON or YAML
+func compileSchema(schemaText string) (*jsonschema.Schema, error) {
+	schemaDocument, err := decodeYAMLDocument(schemaText)
+	if err != nil {
+		return nil, err
+	}
+
+	compiler := jsonschema.NewCompiler()
+	err = compiler.AddResource(structuredSchemaLocation, schemaDocument)
+	if err != nil {
+		return nil, err
+	}
+
+	return compiler.Compile(structuredSchemaLocation)
+}
+
+// decodeStructuredValue decodes the value of a structured field into a document that
+// can be validated against a JSON Schema and encoded as JSON.
+func (field Field) decodeStructuredValue(value string) (interface{}, error) {
+	if field.Properties.Type == "yaml" {
+		return decodeYAMLDocument(value)
+	}
+
+	return jsonschema.UnmarshalJSON(strings.NewReader(value))
+}
+
+// decodeYAMLDocument decodes the given YAML (or JSON) text into a JSON compatible document
+func decodeYAMLDocument(text string) (interface{}, error) {
+	var document interface{}
+	err := yaml.Unmarshal([]byte(text), &document)
+	if err != nil {
+		return nil, err
+	}
+
+	// round trip the document through JSON so it only holds JSON types
+	encoded, err := json.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("document can't be represented as JSON: %w", err)
+	}
+
+	return jsonschema.UnmarshalJSON(bytes.NewReader(encoded))
+}
+
+// validateStructuredValue returns a message describing why the value is not a valid
+// document for the structured field, or an empty string if it is valid.
+func (field Field) validateStructuredValue(value string) string {
+	document, err := field.decodeStructuredValue(value)
+	if err != nil {
+		return fmt.Sprintf("Must be valid %s: %s", strings.ToUpper(field.Properties.Type), err)
+	}
+
+	if field.Properties.schema == nil {
+		return ""
+	}
+
+	err = field.Properties.schema.Validate(document)
+	if err == nil {
+		return ""
+	}
+
+	validationError, ok := err.(*jsonschema.ValidationError)
+	if !ok {
+		return fmt.Sprintf("Does not match the schema: %s", err)
+	}
+
+	var problems []string
+	for _, unit := range validationError.BasicOutput().Errors {
+		if unit.Error == nil {
+			continue
+		}
+
+		location := unit.InstanceLocation
+		if location == "" {
+			location = "/"
+		}
+		problems = append(problems, fmt.Sprintf("at '%s': %s", location, unit.Error))
+	}
+
+	return fmt.Sprintf("Does not match the schema: %s", strings.Join(problems, "; "))
+}
+
+// structuredOutput returns the value of the structured field as compact JSON
+func (field Field) structuredOutput(value string) (string, error) {
+	document, err := field.decodeStructuredValue(value)
+	if err != nil {
+		return "", err
+	}
+
+	output, err := json.Marshal(document)
+	if err != nil {
+		return "", err
+	}
+
+	return string(output), nil
+}
@@ -0,0 +1,178 @@
+package fields_test
+
+import (
+	"bytes"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/boasihq/interactive-inputs/internal/fields"
+	"github.com/sethvargo/go-githubactions"
+	"github.com/stretchr/testify/assert"
+)
+
+const replicasSchema string = `
+type: object
+required: [replicas]
+properties:
+  replicas:
+    type: integer
+    minimum: 1
+`
+
+func TestMarshalStringIntoValidFieldsStruct_Structured(t *testing.T) {
+	workspace := t.TempDir()
+	err := os.WriteFile(filepath.Join(workspace, "schema.json"), []byte(`{"type": "array", "items": {"type": "string"}}`), 0o644)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name                 string
+		fieldsString         string
+		expectedError        bool
+		expectedDefaultValue string
+		expectedOutput       string
+	}{
+		{
+			name:                 "success - json default value is pretty-printed",
+			fieldsString:         "fields:\n  - label: overrides\n    properties:\n      type: json\n      defaultValue: '{\"replicas\": 2}'\n",
+			expectedError:        false,
+			expectedDefaultValue: "{\n  \"replicas\": 2\n}",
+		},
+		{
+			name:                 "success - yaml field with schema from workspace file",
+			fieldsString:         "fields:\n  - label: regions\n    properties:\n      type: yaml\n      schemaFile: schema.json\n      defaultValue: '[eu-west-1]'\n",
+			expectedError:        false,
+			expectedDefaultValue: "[eu-west-1]",
+		},
+		{
+			name:           "failed - default value does not match the schema",
+			fieldsString:   "fields:\n  - label: regions\n    properties:\n      type: yaml\n      schemaFile: schema.json\n      defaultValue: '[1]'\n",
+			expectedError:  true,
+			expectedOutput: "::error::Invalid default value provided for field 'regions': Does not match the schema: at '/0': got number, want string\n",
+		},
+		{
+			name:           "failed - invalid json default value",
+			fieldsString:   "fields:\n  - label: overrides\n    properties:\n      type: json\n      defaultValue: '{replicas: 2}'\n",
+			expectedError:  true,
+			expectedOutput: "::error::Invalid default value provided for field 'overrides': Must be valid JSON: invalid character 'r' looking for beginning of object key string\n",
+		},
+		{
+			name:           "failed - missing schema file",
+			fieldsString:   "fields:\n  - label: overrides\n    properties:\n      type: json\n      schemaFile: missing.json\n",
+			expectedError:  true,
+			expectedOutput: "::error::Unable to read the schema file provided for field 'overrides': open " + filepath.Join(workspace, "missing.json") + ": no such file or directory\n",
+		},
+		{
+			name:           "failed - both inline schema and schema file",
+			fieldsString:   "fields:\n  - label: overrides\n    properties:\n      type: json\n      schema: '{}'\n      schemaFile: schema.json\n",
+			expectedError:  true,
+			expectedOutput: "::error::Invalid schema provided for field 'overrides' - only one of schema or schemaFile can be provided\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actionLog := bytes.NewBuffer(nil)
+			action := githubactions.New(
+				githubactions.WithWriter(actionLog),
+				githubactions.WithGetenv(func(key string) string {
+					if key == "GITHUB_WORKSPACE" {
+						return workspace
+					}
+					return ""
+				}),
+			)
+
+			result, err := fields.MarshalStringIntoValidFieldsStruct(tt.fieldsString, action)
+
+			assert.Equal(t, tt.expectedOutput, actionLog.String())
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedDefaultValue, result.Fields[0].Properties.DefaultValue)
+		})
+	}
+}
+
+func TestFields_ValidateStructured(t *testing.T) {
+	action := githubactions.New(githubactions.WithWriter(bytes.NewBuffer(nil)))
+	f, err := fields.MarshalStringIntoValidFieldsStruct(
+		"fields:\n  - label: overrides\n    properties:\n      type: json\n      schema: |\n"+indent(replicasSchema, "        ")+
+			"  - label: values\n    properties:\n      type: yaml\n",
+		action,
+	)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		form           url.Values
+		expectedErrors fields.FieldErrors
+	}{
+		{
+			name:           "valid documents",
+			form:           url.Values{"overrides": {`{"replicas": 3}`}, "values": {"image:\n  tag: v1\n"}},
+			expectedErrors: fields.FieldErrors{},
+		},
+		{
+			name: "documents that can't be parsed",
+			form: url.Values{"overrides": {`{"replicas": }`}, "values": {"image: [v1"}},
+			expectedErrors: fields.FieldErrors{
+				"overrides": "Must be valid JSON: invalid character '}' looking for beginning of value",
+				"values":    "Must be valid YAML: yaml: line 1: did not find expected ',' or ']'",
+			},
+		},
+		{
+			name:           "document that does not match the schema",
+			form:           url.Values{"overrides": {`{"replicas": 0}`}},
+			expectedErrors: fields.FieldErrors{"overrides": "Does not match the schema: at '/replicas': minimum: got 0, want 1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedErrors, f.Validate(tt.form, "", fields.TemplateData{}))
+		})
+	}
+}
+
+func TestFields_OutputsStructured(t *testing.T) {
+	f := &fields.Fields{
+		Fields: []fields.Field{
+			{Label: "overrides", Properties: fields.FieldProperties{Type: "json"}},
+			{Label: "values", Properties: fields.FieldProperties{Type: "yaml"}},
+			{Label: "extra", Properties: fields.FieldProperties{Type: "json"}},
+		},
+	}
+
+	outputs, err := f.Outputs(url.Values{
+		"overrides": {"{\n  \"replicas\": 3,\n  \"ratio\": 0.25\n}"},
+		"values":    {"image:\n  tag: v1\nports: [80, 443]\n"},
+		"extra":     {"  "},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []fields.Output{
+		{Label: "overrides", Value: `{"ratio":0.25,"replicas":3}`},
+		{Label: "values", Value: `{"image":{"tag":"v1"},"ports":[80,443]}`},
+		{Label: "extra", Value: ""},
+	}, outputs)
+}
+
+// indent prefixes each non-empty line of the given text with the prefix
+func indent(text string, prefix string) string {
+	var indented bytes.Buffer
+	for _, line := range bytes.Split([]byte(text), []byte("\n")) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		indented.WriteString(prefix)
+		indented.Write(line)
+		indented.WriteByte('\n')
+	}
+	return indented.String()
+}
@@ -0,0 +1,224 @@
+package fields
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/sethvargo/go-githubactions"
+)
+
+// TemplateData holds the data that templated field properties can reference
+type TemplateData struct {
+
+	// Answers holds the values entered so far by the user, keyed by field label
+	Answers map[string]string
+
+	// GitHub is the context of the workflow run, i.e. {{ .GitHub.Repository }}
+	GitHub *githubactions.GitHubContext
+
+	// Env holds the environment variables available to the action, i.e. {{ .Env.VERSION }}
+	Env map[string]string
+}
+
+// Environment returns the environment variables available to the action, keyed by name,
+// so they can be referenced by templated properties
+func Environment() map[string]string {
+	var environment map[string]string = make(map[string]string)
+	for _, variable := range os.Environ() {
+		if name, value, ok := strings.Cut(variable, "="); ok {
+			environment[name] = value
+		}
+	}
+
+	return environment
+}
+
+// IsTemplated returns whether the given text contains template actions that
+// need to be expanded before it is displayed.
+func IsTemplated(text string) bool {
+	return strings.Contains(text, "{{")
+}
+
+// ExpandTemplate expands the template actions within the given text using the
+// provided data. Text without template actions is returned as is.
+//
+// Earlier answers can be referenced with either {{ .Answers.label }} or, for
+// labels containing hyphens, {{ answer "my-label" }}. Environment variables and
+// values within the event payload are referenced with {{ env "NAME" }} and
+// {{ event "pull_request.title" }}.
+func ExpandTemplate(text string, data TemplateData) (string, error) {
+	if !IsTemplated(text) {
+		return text, nil
+	}
+
+	tmpl, err := newPropertyTemplate(data).Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	// make sure references to the GitHub context don't fail when it's unavailable
+	if data.GitHub == nil {
+		data.GitHub = &githubactions.GitHubContext{}
+	}
+
+	var expanded bytes.Buffer
+	err = tmpl.Execute(&expanded, data)
+	if err != nil {
+		return "", err
+	}
+
+	return expanded.String(), nil
+}
+
+// TemplatedDefaults returns the expanded default values of the fields within the
+// given step whose default value is templated, keyed by field label.
+func (f *Fields) TemplatedDefaults(stepLabel string, data TemplateData) (map[string]string, error) {
+	var defaults map[string]string = make(map[string]string)
+
+	for _, field := range f.Fields {
+		if field.Properties.Step != stepLabel || !IsTemplated(field.Properties.DefaultValue) {
+			continue
+		}
+
+		value, err := ExpandTemplate(field.Properties.DefaultValue, data)
+		if err != nil {
+			return nil, err
+		}
+
+		defaults[field.Label] = value
+	}
+
+	return defaults, nil
+}
+
+// TemplatedPhrases returns the expanded confirmation phrases of the confirm fields
+// within the given step whose phrase is templated, keyed by field label.
+func (f *Fields) TemplatedPhrases(stepLabel string, data TemplateData) (map[string]string, error) {
+	var phrases map[string]string = make(map[string]string)
+
+	for _, field := range f.Fields {
+		if field.Properties.Step != stepLabel || !IsTemplated(field.Properties.Phrase) {
+			continue
+		}
+
+		phrase, err := ExpandTemplate(field.Properties.Phrase, data)
+		if err != nil {
+			return nil, err
+		}
+
+		phrases[field.Label] = phrase
+	}
+
+	return phrases, nil
+}
+
+// WithExpandedProperties returns a copy of the fields with each templated default
+// value, placeholder, description and confirmation phrase expanded using the provided data.
+func (f *Fields) WithExpandedProperties(data TemplateData) (*Fields, error) {
+	expanded := *f
+	expanded.Fields = make([]Field, len(f.Fields))
+	copy(expanded.Fields, f.Fields)
+
+	for i := range expanded.Fields {
+		properties := &expanded.Fields[i].Properties
+
+		for _, property := range []*string{&properties.DefaultValue, &properties.Placeholder, &properties.Description, &properties.Phrase} {
+			value, err := ExpandTemplate(*property, data)
+			if err != nil {
+				return nil, fmt.Errorf("field '%s': %w", expanded.Fields[i].Label, err)
+			}
+			*property = value
+		}
+	}
+
+	return &expanded, nil
+}
+
+// ValidateTemplate makes sure the given text can be parsed as a template
+func ValidateTemplate(text string) error {
+	if !IsTemplated(text) {
+		return nil
+	}
+
+	_, err := newPropertyTemplate(TemplateData{}).Parse(text)
+	return err
+}
+
+// newPropertyTemplate returns a template, with the helper functions available to
+// templated properties, that does not fail on references to missing answers.
+func newPropertyTemplate(data TemplateData) *template.Template {
+	return template.New("property").
+		Option("missingkey=zero").
+		Funcs(template.FuncMap{
+			"answer": func(label string) string {
+				return data.Answers[label]
+			},
+			"env": func(name string) string {
+				return data.Env[name]
+			},
+			"event": func(path string) string {
+				if data.GitHub == nil {
+					return ""
+				}
+				return eventValue(data.GitHub.Event, path)
+			},
+			"default": func(fallback string, value string) string {
+				if strings.TrimSpace(value) == "" {
+					return fallback
+				}
+				return value
+			},
+			"trimPrefix": func(prefix string, text string) string {
+				return strings.TrimPrefix(text, prefix)
+			},
+			"trimSuffix": func(suffix string, text string) string {
+				return strings.TrimSuffix(text, suffix)
+			},
+			"replace": func(old string, new string, text string) string {
+				return strings.ReplaceAll(text, old, new)
+			},
+			"trim":  strings.TrimSpace,
+			"lower": strings.ToLower,
+			"upper": strings.ToUpper,
+		})
+}
+
+// eventValue returns the value at the dot separated path within the event payload,
+// or an empty string if there is no value at the path. Objects and arrays are
+// returned as JSON.
+func eventValue(event map[string]interface{}, path string) string {
+	var value interface{} = event
+	for _, key := range strings.Split(path, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+
+		value, ok = object[key]
+		if !ok {
+			return ""
+		}
+	}
+
+	switch typedValue := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typedValue
+	case float64:
+		return strconv.FormatFloat(typedValue, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(typedValue)
+	default:
+		encoded, err := json.Marshal(typedValue)
+		if err != nil {
+			return ""
+		}
+		return string(encoded)
+	}
+}
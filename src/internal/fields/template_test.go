@@ -0,0 +1,140 @@
+package fields_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/boasihq/interactive-inputs/internal/fields"
+	"github.com/sethvargo/go-githubactions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandTemplate(t *testing.T) {
+	data := fields.TemplateData{
+		GitHub: &githubactions.GitHubContext{
+			Actor: "octocat",
+			Ref:   "refs/tags/v1.4.0",
+			Event: map[string]interface{}{
+				"pull_request": map[string]interface{}{
+					"title":  "Add region picker",
+					"number": float64(1347),
+					"draft":  false,
+					"labels": []interface{}{"feature"},
+				},
+			},
+		},
+		Env: map[string]string{"BUILD_VERSION": "1.4.0-rc.1"},
+	}
+
+	tests := []struct {
+		name          string
+		text          string
+		expectedText  string
+		expectedError bool
+	}{
+		{
+			name:         "plain text is returned as is",
+			text:         "Release {version}",
+			expectedText: "Release {version}",
+		},
+		{
+			name:         "github context and helpers",
+			text:         `{{ trimPrefix "refs/tags/" .GitHub.Ref }} by {{ upper .GitHub.Actor }}`,
+			expectedText: "v1.4.0 by OCTOCAT",
+		},
+		{
+			name:         "environment variables",
+			text:         `{{ env "BUILD_VERSION" }} {{ .Env.BUILD_VERSION }} {{ env "MISSING" | default "none" }}`,
+			expectedText: "1.4.0-rc.1 1.4.0-rc.1 none",
+		},
+		{
+			name:         "event payload values",
+			text:         `#{{ event "pull_request.number" }} {{ event "pull_request.title" }} {{ event "pull_request.draft" }} {{ event "pull_request.labels" }}`,
+			expectedText: `#1347 Add region picker false ["feature"]`,
+		},
+		{
+			name:         "missing event payload values",
+			text:         `[{{ event "issue.title" }}{{ event "pull_request.title.text" }}]`,
+			expectedText: "[]",
+		},
+		{
+			name:          "unknown function",
+			text:          `{{ shout .GitHub.Actor }}`,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, err := fields.ExpandTemplate(tt.text, data)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedText, text)
+		})
+	}
+}
+
+func TestFields_WithExpandedProperties(t *testing.T) {
+	f := &fields.Fields{
+		Fields: []fields.Field{
+			{Label: "version", Properties: fields.FieldProperties{
+				Type:         "text",
+				DefaultValue: `{{ trimPrefix "refs/tags/" .GitHub.Ref }}`,
+				Placeholder:  `e.g. {{ env "LATEST_VERSION" }}`,
+				Description:  `Requested by **{{ .GitHub.Actor }}**`,
+			}},
+		},
+	}
+
+	expanded, err := f.WithExpandedProperties(fields.TemplateData{
+		GitHub: &githubactions.GitHubContext{Actor: "octocat", Ref: "refs/tags/v1.4.0"},
+		Env:    map[string]string{"LATEST_VERSION": "v1.3.2"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, fields.FieldProperties{
+		Type:         "text",
+		DefaultValue: "v1.4.0",
+		Placeholder:  "e.g. v1.3.2",
+		Description:  "Requested by **octocat**",
+	}, expanded.Fields[0].Properties)
+
+	// the original fields are left untouched so they can be expanded again
+	assert.Equal(t, `{{ trimPrefix "refs/tags/" .GitHub.Ref }}`, f.Fields[0].Properties.DefaultValue)
+}
+
+func TestMarshalStringIntoValidFieldsStruct_InvalidPropertyTemplates(t *testing.T) {
+	tests := []struct {
+		name           string
+		fieldsString   string
+		expectedOutput string
+	}{
+		{
+			name:           "invalid placeholder template",
+			fieldsString:   "fields:\n  - label: version\n    properties:\n      type: text\n      placeholder: '{{ .GitHub.Ref '\n",
+			expectedOutput: "::error::Invalid placeholder template provided for field 'version': template: property:1: unclosed action\n",
+		},
+		{
+			name:           "invalid description template",
+			fieldsString:   "fields:\n  - label: version\n    properties:\n      type: text\n      description: '{{ shout .GitHub.Actor }}'\n",
+			expectedOutput: "::error::Invalid description template provided for field 'version': template: property:1: function \"shout\" not defined\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actionLog := bytes.NewBuffer(nil)
+			action := githubactions.New(githubactions.WithWriter(actionLog))
+
+			_, err := fields.MarshalStringIntoValidFieldsStruct(tt.fieldsString, action)
+
+			assert.Error(t, err)
+			assert.Equal(t, tt.expectedOutput, actionLog.String())
+		})
+	}
+}
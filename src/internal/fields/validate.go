@@ -0,0 +1,142 @@
+package fields
+
+import (
+	"net/url"
+	"strings"
+)
+
+// FieldErrors maps a field's label to a human-friendly description of why the value
+// submitted for the field is invalid.
+type FieldErrors map[string]string
+
+// Merge adds the given field errors, replacing any already held for the same field, and
+// returns the combined errors
+func (e FieldErrors) Merge(fieldErrors FieldErrors) FieldErrors {
+	for label, message := range fieldErrors {
+		e[label] = message
+	}
+
+	return e
+}
+
+// Validate checks the submitted form values against the properties of the fields
+// within the given step, followed by the rules relevant to the step. If the step is
+// empty, all of the fields and rules are validated. The data is used to expand
+// templated properties, with its answers taken from the submitted values.
+func (f *Fields) Validate(form url.Values, stepLabel string, data TemplateData) FieldErrors {
+	var fieldErrors FieldErrors = make(FieldErrors)
+
+	data.Answers = f.Answers(form)
+
+	for _, field := range f.Fields {
+		fieldType := field.FieldType()
+		if (stepLabel != "" && field.Properties.Step != stepLabel) || fieldType.DisplayOnly || fieldType.Validate == nil {
+			continue
+		}
+
+		for inputName, message := range fieldType.Validate(field, form, data) {
+			fieldErrors[inputName] = message
+		}
+	}
+
+	f.validateRules(form, stepLabel, fieldErrors)
+
+	return fieldErrors
+}
+
+// EnforceReadOnly gives read-only fields their default value in the form, expanded with the
+// provided data, replacing whatever was submitted for them, along with the items of read-only
+// group fields. Read-only fields are displayed disabled, so the browser doesn't submit them
+// and a value submitted for one can only have been crafted.
+func (f *Fields) EnforceReadOnly(form url.Values, data TemplateData) {
+	for _, field := range f.Fields {
+		if !field.Properties.ReadOnly || field.FieldType().DisplayOnly {
+			continue
+		}
+
+		for key := range form {
+			if strings.HasPrefix(key, field.Label+groupInputNameSeparator) {
+				form.Del(key)
+			}
+		}
+
+		defaultValue := field.Properties.DefaultValue
+		if IsTemplated(defaultValue) {
+			if expanded, err := ExpandTemplate(defaultValue, data); err == nil {
+				defaultValue = expanded
+			}
+		}
+
+		form.Set(field.Label, defaultValue)
+	}
+}
+
+// Output represents the value that will be set as the output for a field. Secret is
+// true when the value must be masked in the logs.
+type Output struct {
+	Label  string
+	Value  string
+	Secret bool
+}
+
+// Outputs returns the outputs for the submitted form values in the order the fields
+// are defined. Fields without a submitted value, file fields whose values are held
+// in the runner's cache, confirm fields and display-only fields, are skipped.
+func (f *Fields) Outputs(form url.Values) ([]Output, error) {
+	var outputs []Output = make([]Output, 0, len(f.Fields))
+
+	for _, field := range f.Fields {
+		value, ok, err := field.output(form)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			outputs = append(outputs, Output{Label: field.Label, Value: value, Secret: field.Properties.Secret})
+		}
+	}
+
+	return outputs, nil
+}
+
+// Answers returns the submitted form values of the fields keyed by field label, with
+// multiple values joined in the same way they are set as outputs. Values that can't
+// be converted to an output, as they are invalid, are used as submitted.
+func (f *Fields) Answers(form url.Values) map[string]string {
+	var answers map[string]string = make(map[string]string)
+
+	for _, field := range f.Fields {
+		value, ok, err := field.output(form)
+		if err != nil {
+			value, ok = strings.Join(form[field.Label], ","), true
+		}
+
+		if ok {
+			answers[field.Label] = value
+		}
+	}
+
+	return answers
+}
+
+// output returns the output for the field from the submitted form values, and
+// whether the field has an output.
+func (field Field) output(form url.Values) (string, bool, error) {
+	fieldType := field.FieldType()
+	if fieldType.DisplayOnly || fieldType.Output == nil {
+		return "", false, nil
+	}
+
+	return fieldType.Output(field, form)
+}
+
+// validateValues returns a message describing why the submitted values are not valid
+// for the field, or an empty string if they are valid.
+func (field Field) validateValues(values []string) string {
+	validate := field.FieldType().Validate
+	if validate == nil {
+		return ""
+	}
+
+	return validate(field, url.Values{field.Label: values}, TemplateData{})[field.Label]
+}
@@ -0,0 +1,115 @@
+package fields_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/boasihq/interactive-inputs/internal/fields"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFields_Validate(t *testing.T) {
+	f := &fields.Fields{
+		Steps: []fields.Step{{Label: "target"}, {Label: "details"}},
+		Fields: []fields.Field{
+			{Label: "environment", Properties: fields.FieldProperties{Type: "select", Step: "target", Required: true, Choices: []fields.Choice{
+				{Label: "Staging", Value: "stg"},
+				{Label: "Production", Value: "prod", Disabled: true},
+			}}},
+			{Label: "regions", Properties: fields.FieldProperties{Type: "multiselect", Step: "target", Choices: []fields.Choice{
+				{Label: "eu-west-1", Value: "eu-west-1"},
+				{Label: "us-east-1", Value: "us-east-1"},
+			}}},
+			{Label: "replicas", Properties: fields.FieldProperties{Type: "number", Step: "details", NumberMin: numberPtr(1), NumberMax: numberPtr(5)}},
+			{Label: "name", Properties: fields.FieldProperties{Type: "text", Step: "details", MaxLength: 5}},
+			{Label: "notify", Properties: fields.FieldProperties{Type: "boolean", Step: "details"}},
+			{Label: "upload", Properties: fields.FieldProperties{Type: "file", Step: "details", Required: true}},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		form           url.Values
+		step           string
+		expectedErrors fields.FieldErrors
+	}{
+		{
+			name:           "valid values across all steps",
+			form:           url.Values{"environment": {"stg"}, "regions": {"eu-west-1", "us-east-1"}, "replicas": {"3"}, "name": {"héllo"}, "notify": {"true"}},
+			expectedErrors: fields.FieldErrors{},
+		},
+		{
+			name: "invalid values across all steps",
+			form: url.Values{"environment": {"prod"}, "regions": {"eu-west-1", "ap-south-1"}, "replicas": {"9"}, "name": {"too long"}, "notify": {"yes"}},
+			expectedErrors: fields.FieldErrors{
+				"environment": "'prod' is not one of the available options",
+				"regions":     "'ap-south-1' is not one of the available options",
+				"replicas":    "Must be at most 5",
+				"name":        "Must be at most 5 characters long",
+				"notify":      "Must be either true or false",
+			},
+		},
+		{
+			name:           "only the given step is validated",
+			form:           url.Values{"replicas": {"zero"}},
+			step:           "target",
+			expectedErrors: fields.FieldErrors{"environment": "This field is required"},
+		},
+		{
+			name:           "non numeric number value",
+			form:           url.Values{"replicas": {"zero"}},
+			step:           "details",
+			expectedErrors: fields.FieldErrors{"replicas": "Must be a number"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedErrors, f.Validate(tt.form, tt.step, fields.TemplateData{}))
+		})
+	}
+}
+
+// numberPtr returns a pointer to the given number, for optional number properties
+func numberPtr(number float64) *float64 {
+	return &number
+}
+
+func TestFields_EnforceReadOnly(t *testing.T) {
+	f := &fields.Fields{
+		Fields: []fields.Field{
+			{Label: "environment", Properties: fields.FieldProperties{Type: "text"}},
+			{Label: "replicas", Properties: fields.FieldProperties{Type: "number", ReadOnly: true, DefaultValue: "3"}},
+			{Label: "target", Properties: fields.FieldProperties{Type: "text", ReadOnly: true, DefaultValue: "deploy to {{ .Answers.environment }}"}},
+			{Label: "notes", Properties: fields.FieldProperties{Type: "text", ReadOnly: true}},
+			{Label: "services", Properties: fields.FieldProperties{Type: "group", ReadOnly: true, MinItems: 1, Fields: []fields.Field{
+				{Label: "name", Properties: fields.FieldProperties{Type: "text", Required: true}},
+			}}},
+		},
+	}
+
+	// values crafted for read-only fields are replaced, including the items of groups, and
+	// read-only fields the browser didn't submit are given their default value
+	form := url.Values{"environment": {"production"}, "replicas": {"99"}, "notes": {"crafted"}, "services.0.name": {"crafted"}}
+	f.EnforceReadOnly(form, fields.TemplateData{Answers: f.Answers(form)})
+
+	assert.Equal(t, url.Values{
+		"environment": {"production"},
+		"replicas":    {"3"},
+		"target":      {"deploy to production"},
+		"notes":       {""},
+		"services":    {""},
+	}, form)
+
+	assert.Equal(t, fields.FieldErrors{}, f.Validate(form, "", fields.TemplateData{}))
+
+	outputs, err := f.Outputs(form)
+	assert.NoError(t, err)
+	assert.Equal(t, []fields.Output{
+		{Label: "environment", Value: "production"},
+		{Label: "replicas", Value: "3"},
+		{Label: "target", Value: "deploy to production"},
+		{Label: "notes", Value: ""},
+		{Label: "services", Value: "[]"},
+	}, outputs)
+}
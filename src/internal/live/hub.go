@@ -0,0 +1,197 @@
+package live
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// OutcomeSubmitted is the outcome of a portal submitted as a form
+	OutcomeSubmitted string = "submitted"
+
+	// OutcomeCancelled is the outcome of a cancelled portal
+	OutcomeCancelled string = "cancelled"
+
+	// OutcomeApproved is the outcome of a portal approved in decision mode
+	OutcomeApproved string = "approved"
+
+	// OutcomeRejected is the outcome of a portal rejected in decision mode
+	OutcomeRejected string = "rejected"
+
+	// OutcomeAcknowledged is the outcome of a portal acknowledged in gate mode
+	OutcomeAcknowledged string = "acknowledged"
+)
+
+// Outcome is how the portal was completed
+type Outcome struct {
+
+	// Kind is how the portal was completed, i.e. submitted
+	Kind string `json:"kind"`
+
+	// By is who completed the portal, empty when they couldn't be identified
+	By string `json:"by,omitempty"`
+
+	// At is when the portal was completed
+	At time.Time `json:"at"`
+}
+
+// State is the live state of the portal, as shared with everyone viewing it
+type State struct {
+
+	// Viewers are the distinct identities viewing the portal, sorted by name. Viewers who
+	// couldn't be identified are only included in the viewer count
+	Viewers []string `json:"viewers"`
+
+	// ViewerCount is the number of viewers, including those who couldn't be identified
+	ViewerCount int `json:"viewer_count"`
+
+	// Locked is true once the portal no longer accepts responses, as it has been completed
+	// or has expired
+	Locked bool `json:"locked"`
+
+	// ExpiresAt is when the portal expires, nil when it doesn't
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// RemainingSeconds is how long is left before the portal expires, zero once it has or
+	// when it doesn't expire
+	RemainingSeconds int `json:"remaining_seconds"`
+
+	// Outcome is how the portal was completed, nil until it has been
+	Outcome *Outcome `json:"outcome,omitempty"`
+}
+
+// NewHubRequest holds everything needed to create a hub
+type NewHubRequest struct {
+
+	// ExpiresAt is when the portal expires, the portal doesn't expire when zero
+	ExpiresAt time.Time
+}
+
+// Hub keeps track of who is viewing the portal and how it was completed, telling each
+// viewer when the state of the portal changes. It is safe for concurrent use.
+type Hub struct {
+	mu sync.Mutex
+
+	// expiresAt is when the portal expires
+	expiresAt time.Time
+
+	// nextViewerId is the id given to the next viewer to join
+	nextViewerId int
+
+	// viewers are the identities of the viewers keyed by viewer id
+	viewers map[int]string
+
+	// updates are the channels the viewers are told about changes on, keyed by viewer id
+	updates map[int]chan struct{}
+
+	// outcome is how the portal was completed, nil until it has been
+	outcome *Outcome
+}
+
+// NewHub returns a hub without any viewers, for a portal that hasn't been completed
+func NewHub(r *NewHubRequest) *Hub {
+	return &Hub{
+		expiresAt: r.ExpiresAt,
+		viewers:   make(map[int]string),
+		updates:   make(map[int]chan struct{}),
+	}
+}
+
+// Join adds a viewer with the given identity, which is empty when they can't be identified.
+// It returns the viewer's id, used to leave, and a channel that receives when the state of
+// the portal changes. Changes made while the last one hasn't been received are merged.
+func (h *Hub) Join(identity string) (int, <-chan struct{}) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	viewerId := h.nextViewerId
+	h.nextViewerId++
+
+	h.viewers[viewerId] = identity
+	h.updates[viewerId] = make(chan struct{}, 1)
+	h.notify()
+
+	return viewerId, h.updates[viewerId]
+}
+
+// Leave removes the viewer with the given id
+func (h *Hub) Leave(viewerId int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.viewers[viewerId]; !ok {
+		return
+	}
+
+	delete(h.viewers, viewerId)
+	delete(h.updates, viewerId)
+	h.notify()
+}
+
+// Complete records how the portal was completed, returning false when it had already been
+func (h *Hub) Complete(outcome Outcome) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.outcome != nil {
+		return false
+	}
+
+	if outcome.At.IsZero() {
+		outcome.At = time.Now().UTC()
+	}
+
+	h.outcome = &outcome
+	h.notify()
+
+	return true
+}
+
+// State returns the current state of the portal
+func (h *Hub) State() State {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var state State = State{Viewers: []string{}, ViewerCount: len(h.viewers)}
+	var seen map[string]bool = make(map[string]bool)
+
+	for _, identity := range h.viewers {
+		if identity == "" || seen[identity] {
+			continue
+		}
+
+		seen[identity] = true
+		state.Viewers = append(state.Viewers, identity)
+	}
+	sort.Strings(state.Viewers)
+
+	if !h.expiresAt.IsZero() {
+		expiresAt := h.expiresAt
+		state.ExpiresAt = &expiresAt
+
+		if remaining := time.Until(expiresAt); remaining > 0 {
+			state.RemainingSeconds = int(remaining.Seconds())
+		}
+		state.Locked = state.RemainingSeconds == 0
+	}
+
+	if h.outcome != nil {
+		outcome := *h.outcome
+		state.Outcome = &outcome
+		state.Locked = true
+	}
+
+	return state
+}
+
+// notify tells each viewer the state of the portal has changed, without waiting for
+// viewers that haven't received the last change. The hub must be locked.
+func (h *Hub) notify() {
+	for _, update := range h.updates {
+		select {
+		case update <- struct{}{}:
+		default:
+		}
+	}
+}
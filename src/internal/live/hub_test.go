@@ -0,0 +1,106 @@
+package live_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/boasihq/interactive-inputs/internal/live"
+	"github.com/stretchr/testify/assert"
+)
+
+// received returns whether the update channel has received a change
+func received(updates <-chan struct{}) bool {
+	select {
+	case <-updates:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestHub_Presence(t *testing.T) {
+	hub := live.NewHub(&live.NewHubRequest{})
+
+	aliceId, aliceUpdates := hub.Join("alice")
+	assert.True(t, received(aliceUpdates))
+
+	bobId, bobUpdates := hub.Join("bob")
+	_, _ = hub.Join("alice")
+	_, _ = hub.Join("")
+
+	// changes that haven't been received are merged
+	assert.True(t, received(aliceUpdates))
+	assert.False(t, received(aliceUpdates))
+	assert.True(t, received(bobUpdates))
+
+	state := hub.State()
+	assert.Equal(t, []string{"alice", "bob"}, state.Viewers)
+	assert.Equal(t, 4, state.ViewerCount)
+	assert.False(t, state.Locked)
+	assert.Nil(t, state.ExpiresAt)
+	assert.Nil(t, state.Outcome)
+
+	hub.Leave(bobId)
+	hub.Leave(aliceId)
+	assert.False(t, received(bobUpdates))
+
+	state = hub.State()
+	assert.Equal(t, []string{"alice"}, state.Viewers)
+	assert.Equal(t, 2, state.ViewerCount)
+
+	// leaving twice doesn't change anything
+	hub.Leave(aliceId)
+	assert.Equal(t, 2, hub.State().ViewerCount)
+}
+
+func TestHub_Complete(t *testing.T) {
+	hub := live.NewHub(&live.NewHubRequest{})
+	_, updates := hub.Join("bob")
+	<-updates
+
+	assert.True(t, hub.Complete(live.Outcome{Kind: live.OutcomeSubmitted, By: "alice"}))
+	assert.True(t, received(updates))
+
+	// only the first outcome is recorded
+	assert.False(t, hub.Complete(live.Outcome{Kind: live.OutcomeCancelled, By: "bob"}))
+	assert.False(t, received(updates))
+
+	state := hub.State()
+	assert.True(t, state.Locked)
+	assert.Equal(t, live.OutcomeSubmitted, state.Outcome.Kind)
+	assert.Equal(t, "alice", state.Outcome.By)
+	assert.False(t, state.Outcome.At.IsZero())
+}
+
+func TestHub_Expiry(t *testing.T) {
+	tests := []struct {
+		name                     string
+		expiresAt                time.Time
+		expectedLocked           bool
+		expectedMinimumRemaining int
+	}{
+		{
+			name:                     "the remaining time is counted down to the expiry",
+			expiresAt:                time.Now().Add(time.Minute),
+			expectedLocked:           false,
+			expectedMinimumRemaining: 58,
+		},
+		{
+			name:                     "the portal is locked once it has expired",
+			expiresAt:                time.Now().Add(-time.Second),
+			expectedLocked:           true,
+			expectedMinimumRemaining: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state := live.NewHub(&live.NewHubRequest{ExpiresAt: test.expiresAt}).State()
+
+			assert.Equal(t, test.expectedLocked, state.Locked)
+			assert.True(t, test.expiresAt.Equal(*state.ExpiresAt))
+			assert.GreaterOrEqual(t, state.RemainingSeconds, test.expectedMinimumRemaining)
+			assert.LessOrEqual(t, state.RemainingSeconds, 60)
+		})
+	}
+}
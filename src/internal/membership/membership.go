@@ -0,0 +1,181 @@
+package membership
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/boasihq/interactive-inputs/internal/errors"
+)
+
+// Checker checks whether users are members of GitHub teams
+type Checker interface {
+
+	// IsMember returns whether the user is an active member of the team, given as org/team-slug
+	IsMember(team, user string) (bool, error)
+}
+
+// NewGitHubCheckerRequest holds everything needed to create a GitHub team membership checker
+type NewGitHubCheckerRequest struct {
+
+	// Token is the token used to call the GitHub API, it must be able to read the
+	// organisation's teams
+	Token string
+
+	// ApiUrl is the base URL of the GitHub API, defaults to https://api.github.com
+	ApiUrl string
+
+	// HttpClient is the client used to call the GitHub API, defaults to a client with a timeout
+	HttpClient *http.Client
+}
+
+// NewGitHubChecker returns a checker that looks up team membership with the GitHub API. The
+// membership of each user is cached for the lifetime of the checker, which is the lifetime
+// of the portal.
+func NewGitHubChecker(r *NewGitHubCheckerRequest) *GitHubChecker {
+	var apiUrl string = "https://api.github.com"
+	var httpClient *http.Client = &http.Client{Timeout: 10 * time.Second}
+
+	if r.ApiUrl != "" {
+		apiUrl = strings.TrimRight(r.ApiUrl, "/")
+	}
+
+	if r.HttpClient != nil {
+		httpClient = r.HttpClient
+	}
+
+	return &GitHubChecker{
+		token:      r.Token,
+		apiUrl:     apiUrl,
+		httpClient: httpClient,
+		cache:      make(map[string]bool),
+	}
+}
+
+// GitHubChecker checks team membership with the GitHub API
+type GitHubChecker struct {
+
+	// token is the token used to call the GitHub API
+	token string
+
+	// apiUrl is the base URL of the GitHub API
+	apiUrl string
+
+	// httpClient is the client used to call the GitHub API
+	httpClient *http.Client
+
+	// mu guards the cache
+	mu sync.Mutex
+
+	// cache holds the membership already looked up, keyed by team and user
+	cache map[string]bool
+}
+
+// IsMember returns whether the user is an active member of the team, given as org/team-slug.
+// Pending invitations aren't treated as membership.
+func (c *GitHubChecker) IsMember(team, user string) (bool, error) {
+	org, teamSlug, ok := SplitTeam(team)
+	if !ok || user == "" {
+		return false, nil
+	}
+
+	cacheKey := strings.ToLower(team + "\n" + user)
+
+	c.mu.Lock()
+	member, cached := c.cache[cacheKey]
+	c.mu.Unlock()
+	if cached {
+		return member, nil
+	}
+
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf(
+		"%s/orgs/%s/teams/%s/memberships/%s",
+		c.apiUrl, url.PathEscape(org), url.PathEscape(teamSlug), url.PathEscape(user),
+	), nil)
+	if err != nil {
+		return false, err
+	}
+
+	request.Header.Set("Accept", "application/vnd.github+json")
+	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	request.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return false, err
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		var membership struct {
+			State string `json:"state"`
+		}
+		if err := json.NewDecoder(response.Body).Decode(&membership); err != nil {
+			return false, err
+		}
+		member = membership.State == "active"
+	case http.StatusNotFound:
+		member = false
+	default:
+		return false, fmt.Errorf("%w: GitHub API responded with %d for team '%s'", errors.ErrUnableToCheckTeamMembership, response.StatusCode, team)
+	}
+
+	c.mu.Lock()
+	c.cache[cacheKey] = member
+	c.mu.Unlock()
+
+	return member, nil
+}
+
+// MemberTeams returns the teams the user is a member of, in the order they are given
+func MemberTeams(checker Checker, teams []string, user string) ([]string, error) {
+	var memberTeams []string
+	for _, team := range teams {
+		member, err := checker.IsMember(team, user)
+		if err != nil {
+			return nil, err
+		}
+
+		if member {
+			memberTeams = append(memberTeams, team)
+		}
+	}
+
+	return memberTeams, nil
+}
+
+// IsMemberOfAny returns whether the user is a member of at least one of the teams
+func IsMemberOfAny(checker Checker, teams []string, user string) (bool, error) {
+	for _, team := range teams {
+		member, err := checker.IsMember(team, user)
+		if err != nil || member {
+			return member, err
+		}
+	}
+
+	return false, nil
+}
+
+// NormaliseTeam returns the team as org/team-slug, without the leading @ it is often
+// written with, and whether it is in that form
+func NormaliseTeam(team string) (string, bool) {
+	team = strings.TrimPrefix(strings.TrimSpace(team), "@")
+	_, _, ok := SplitTeam(team)
+
+	return team, ok
+}
+
+// SplitTeam splits the team, given as org/team-slug, into its organisation and slug
+func SplitTeam(team string) (string, string, bool) {
+	org, teamSlug, ok := strings.Cut(team, "/")
+	if !ok || org == "" || teamSlug == "" || strings.Contains(teamSlug, "/") {
+		return "", "", false
+	}
+
+	return org, teamSlug, true
+}
@@ -0,0 +1,113 @@
+package membership_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/boasihq/interactive-inputs/internal/errors"
+	"github.com/boasihq/interactive-inputs/internal/membership"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitHubChecker_IsMember(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "Bearer github-secret-token", r.Header.Get("Authorization"))
+
+		switch r.URL.Path {
+		case "/orgs/acme/teams/sre/memberships/alice":
+			w.Write([]byte(`{"state": "active", "role": "member"}`))
+		case "/orgs/acme/teams/sre/memberships/bob":
+			w.Write([]byte(`{"state": "pending", "role": "member"}`))
+		case "/orgs/acme/teams/private/memberships/alice":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	checker := membership.NewGitHubChecker(&membership.NewGitHubCheckerRequest{Token: "github-secret-token", ApiUrl: server.URL + "/"})
+
+	tests := []struct {
+		name           string
+		team           string
+		user           string
+		expectedMember bool
+		expectedError  error
+	}{
+		{name: "active member", team: "acme/sre", user: "alice", expectedMember: true},
+		{name: "pending invitation", team: "acme/sre", user: "bob"},
+		{name: "not a member", team: "acme/sre", user: "carol"},
+		{name: "malformed team", team: "sre", user: "alice"},
+		{name: "no user", team: "acme/sre", user: ""},
+		{name: "team can't be read", team: "acme/private", user: "alice", expectedError: errors.ErrUnableToCheckTeamMembership},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			member, err := checker.IsMember(tt.team, tt.user)
+
+			assert.ErrorIs(t, err, tt.expectedError)
+			assert.Equal(t, tt.expectedMember, member)
+		})
+	}
+
+	t.Run("membership is cached", func(t *testing.T) {
+		before := requests
+
+		member, err := checker.IsMember("acme/sre", "alice")
+
+		assert.NoError(t, err)
+		assert.True(t, member)
+		assert.Equal(t, before, requests)
+	})
+}
+
+func TestMemberTeams(t *testing.T) {
+	checker := staticChecker{"acme/sre": {"alice"}, "acme/security": {"alice", "bob"}}
+
+	teams, err := membership.MemberTeams(checker, []string{"acme/sre", "acme/security"}, "alice")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"acme/sre", "acme/security"}, teams)
+
+	teams, err = membership.MemberTeams(checker, []string{"acme/sre", "acme/security"}, "bob")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"acme/security"}, teams)
+}
+
+func TestIsMemberOfAny(t *testing.T) {
+	checker := staticChecker{"acme/sre": {"alice"}, "acme/security": {"bob"}}
+
+	member, err := membership.IsMemberOfAny(checker, []string{"acme/sre", "acme/security"}, "bob")
+
+	assert.NoError(t, err)
+	assert.True(t, member)
+
+	member, err = membership.IsMemberOfAny(checker, []string{"acme/sre", "acme/security"}, "carol")
+
+	assert.NoError(t, err)
+	assert.False(t, member)
+
+	member, err = membership.IsMemberOfAny(checker, nil, "alice")
+
+	assert.NoError(t, err)
+	assert.False(t, member)
+}
+
+// staticChecker is a checker with fixed members, keyed by team
+type staticChecker map[string][]string
+
+func (c staticChecker) IsMember(team, user string) (bool, error) {
+	for _, member := range c[team] {
+		if member == user {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
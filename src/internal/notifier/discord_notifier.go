@@ -82,12 +82,12 @@ type DiscordNotifier struct {
 	threadId string
 }
 
-func (n *DiscordNotifier) Notify(title, message string) (string, error) {
+func (n *DiscordNotifier) Notify(heading, title, message string) (string, error) {
 
 	var discordCompleteWebhookUrl string = n.webhookUrl
 
 	// Shape the message to be sent
-	renderedMessage, err := n.renderStandardDiscordNofityMessage(title, message)
+	renderedMessage, err := n.renderStandardDiscordNofityMessage(heading, title, message)
 	if err != nil {
 		return "", err
 	}
@@ -187,8 +187,13 @@ func (n *DiscordNotifier) Enabled() bool {
 	return n.enabled
 }
 
-// renderStandardDiscordNofityMessage renders the standard Discord notification message.
-func (n *DiscordNotifier) renderStandardDiscordNofityMessage(title, message string) (string, error) {
+// Link returns the text linking to the url, formatted as Discord markdown
+func (n *DiscordNotifier) Link(text, url string) string {
+	return fmt.Sprintf("[**%s**](%s)", text, url)
+}
+
+// renderStandardDiscordNofityMessage renders the standard Discord notification message under the given heading.
+func (n *DiscordNotifier) renderStandardDiscordNofityMessage(heading, title, message string) (string, error) {
 
 	// get action context
 	actionCtx, err := n.action.Context()
@@ -203,7 +208,7 @@ func (n *DiscordNotifier) renderStandardDiscordNofityMessage(title, message stri
 		optionalSentence = fmt.Sprintf("**Title:** *`\"%s\"`* | ", title)
 	}
 
-	defaultNotifyMessageFmt := "**`User Input Required`**" + `
+	defaultNotifyMessageFmt := "**`%s`**" + `
 
 %s[**Go to run**](%s)
 **Initiator:** %s
@@ -219,5 +224,5 @@ func (n *DiscordNotifier) renderStandardDiscordNofityMessage(title, message stri
 		actionCtx.RunID,
 	)
 
-	return fmt.Sprintf(defaultNotifyMessageFmt, optionalSentence, additionalContext, actionCtx.Actor, message), nil
+	return fmt.Sprintf(defaultNotifyMessageFmt, heading, optionalSentence, additionalContext, actionCtx.Actor, message), nil
 }
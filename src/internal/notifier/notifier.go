@@ -1,15 +1,34 @@
 package notifier
 
+const (
+	// HeadingInputRequired heads the notification sent when the portal is waiting for input
+	HeadingInputRequired string = "User Input Required"
+
+	// HeadingAcknowledgementRequired heads the notification sent when a gate is waiting to
+	// be acknowledged
+	HeadingAcknowledgementRequired string = "Acknowledgement Required"
+
+	// HeadingGateAcknowledged heads the notification sent once a gate has been acknowledged
+	HeadingGateAcknowledged string = "Gate Acknowledged"
+
+	// HeadingPortalCompleted heads the notification sent once the portal has been completed,
+	// linking to where its result can be found
+	HeadingPortalCompleted string = "Portal Completed"
+)
+
 type Notifier interface {
 
-	// Notify sends a notification to respective integration
+	// Notify sends a notification, under the given heading, to respective integration
 	// returning the id that can be used for future thread communication
 	// if the integration supports it.
-	Notify(title, message string) (string, error)
+	Notify(heading, title, message string) (string, error)
 
 	// Verifys the connection to the integration
 	Verify() error
 
 	// Enabled returns whether the notifier is enabled or not
 	Enabled() bool
+
+	// Link returns the text linking to the url, formatted for the integration
+	Link(text, url string) string
 }
@@ -86,13 +86,13 @@ type SlackNotifier struct {
 }
 
 // Notify sends a notification to the Slack channel
-func (n *SlackNotifier) Notify(title, message string) (string, error) {
+func (n *SlackNotifier) Notify(heading, title, message string) (string, error) {
 
 	var notificationResponse SlackChatPostMessageResponse
 	var slackPostChatMessageUrl string = "https://slack.com/api/chat.postMessage"
 
 	// Shape the message to be sent
-	renderedMessage, err := n.renderStandardSlackNofityMessage(title, message)
+	renderedMessage, err := n.renderStandardSlackNofityMessage(heading, title, message)
 	if err != nil {
 		return "", err
 	}
@@ -210,8 +210,13 @@ func (n *SlackNotifier) Enabled() bool {
 	return n.enabled
 }
 
-// renderStandardSlackNofityMessage renders the standard Slack notification message.
-func (n *SlackNotifier) renderStandardSlackNofityMessage(title, message string) (string, error) {
+// Link returns the text linking to the url, formatted as Slack mrkdwn
+func (n *SlackNotifier) Link(text, url string) string {
+	return fmt.Sprintf("<%s|*%s*>", url, text)
+}
+
+// renderStandardSlackNofityMessage renders the standard Slack notification message under the given heading.
+func (n *SlackNotifier) renderStandardSlackNofityMessage(heading, title, message string) (string, error) {
 
 	// get action context
 	actionCtx, err := n.action.Context()
@@ -226,7 +231,7 @@ func (n *SlackNotifier) renderStandardSlackNofityMessage(title, message string)
 		optionalSentence = fmt.Sprintf("*Title:* _`\"%s\"`_ | ", title)
 	}
 
-	defaultNotifyMessageFmt := "*`User Input Required`*" + `
+	defaultNotifyMessageFmt := "*`%s`*" + `
 
 %s<%s|*Go to run*>
 *Initiator:* %s
@@ -243,5 +248,5 @@ func (n *SlackNotifier) renderStandardSlackNofityMessage(title, message string)
 		actionCtx.RunID,
 	)
 
-	return fmt.Sprintf(defaultNotifyMessageFmt, optionalSentence, additionalContext, actionCtx.Actor, message), nil
+	return fmt.Sprintf(defaultNotifyMessageFmt, heading, optionalSentence, additionalContext, actionCtx.Actor, message), nil
 }
@@ -4,10 +4,100 @@ const (
 	// InputFieldLabelUriVariableId holds the identifer used for the input label in the URI
 	InputFieldLabelUriVariableId = "inputFieldVariableId"
 
+	// StepLabelUriVariableId holds the identifer used for the step label in the URI
+	StepLabelUriVariableId = "stepVariableId"
+
+	// FieldsInvalidEventName is the name of the event triggered in the portal when
+	// submitted values are rejected
+	FieldsInvalidEventName = "fields-invalid"
+
+	// StateEventName is the name of the Server-Sent Event the live state of the portal is
+	// sent as
+	StateEventName = "state"
+
+	// IdempotencyKeyHeader is the request header holding the key the portal generates when
+	// it is loaded, so repeats of the same submission can be recognised
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	// DecisionFormKey is the form key the decision made in decision mode is submitted under,
+	// and the output it is set as
+	DecisionFormKey = "decision"
+
+	// DecisionReasonFormKey is the form key the reason given for the decision is submitted
+	// under, and the output it is set as
+	DecisionReasonFormKey = "decision-reason"
+
+	// ApproversOutput is the output the identities that approved the request are set as
+	ApproversOutput = "approvers"
+
+	// DecisionApproved is the decision made when the portal is approved
+	DecisionApproved = "approved"
+
+	// DecisionRejected is the decision made when the portal is rejected
+	DecisionRejected = "rejected"
+
+	// ChecklistFormKey is the form key the positions of the checklist items ticked in gate
+	// mode are submitted under
+	ChecklistFormKey = "checklist"
+
+	// AcknowledgedByOutput is the output who acknowledged the gate is set as
+	AcknowledgedByOutput = "acknowledged-by"
+
+	// AcknowledgedAtOutput is the output when the gate was acknowledged is set as
+	AcknowledgedAtOutput = "acknowledged-at"
+
 	// ErrKeyInvalidInputFieldId is returned when the input field label cannot be found for
 	// a targetted request
 	ErrKeyInvalidInputFieldId = "InvalidInputFieldId"
 
+	// ErrKeyInvalidStepId is returned when the step label cannot be found for
+	// a targetted request
+	ErrKeyInvalidStepId = "InvalidStepId"
+
+	// ErrKeyInvalidDecision is returned when the decision submitted isn't approved or rejected,
+	// or the portal isn't in decision mode
+	ErrKeyInvalidDecision = "InvalidDecision"
+
+	// ErrKeyNotAForm is returned when the portal is submitted but is in decision or gate mode,
+	// or cancelled in either, where it must be decided or acknowledged instead
+	ErrKeyNotAForm = "NotAForm"
+
+	// ErrKeyNotAGate is returned when the portal is acknowledged but isn't in gate mode
+	ErrKeyNotAGate = "NotAGate"
+
+	// ErrKeyAlreadyAcknowledged is returned when the gate has already been acknowledged
+	ErrKeyAlreadyAcknowledged = "AlreadyAcknowledged"
+
+	// ErrKeyDraftsNotSaved is returned when a draft is saved but the portal has no fields
+	// whose values can be saved
+	ErrKeyDraftsNotSaved = "DraftsNotSaved"
+
+	// ErrKeyInvalidDraft is returned when the values of a draft can't be read, i.e. as they
+	// are too large
+	ErrKeyInvalidDraft = "InvalidDraft"
+
+	// ErrKeyUnknownDraftSession is returned when a draft is saved for a session that wasn't
+	// started by the portal
+	ErrKeyUnknownDraftSession = "UnknownDraftSession"
+
+	// ErrKeyIdentityMissing is returned when the header identifying who is using the portal
+	// is missing from a request
+	ErrKeyIdentityMissing = "IdentityMissing"
+
+	// ErrKeyNotPermittedToDecide is returned when the identity isn't a member of any of the
+	// approver teams
+	ErrKeyNotPermittedToDecide = "NotPermittedToDecide"
+
+	// ErrKeyAlreadyApproved is returned when the identity has already approved the request
+	ErrKeyAlreadyApproved = "AlreadyApproved"
+
+	// ErrKeyAlreadyDecided is returned when the request has already been approved or rejected
+	ErrKeyAlreadyDecided = "AlreadyDecided"
+
+	// ErrKeyUnableToCheckTeamMembership is returned when the teams of the identity can't be
+	// looked up with the GitHub API
+	ErrKeyUnableToCheckTeamMembership = "UnableToCheckTeamMembership"
+
 	// ErrNoFilesProvidedWithUploadRequest is returned when no files are detected in the
 	// form data of a request
 	ErrNoFilesProvidedWithUploadRequest = "NoFilesProvidedWithUploadRequest"
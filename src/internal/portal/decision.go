@@ -0,0 +1,308 @@
+package portal
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/boasihq/interactive-inputs/internal/approval"
+	internalerrors "github.com/boasihq/interactive-inputs/internal/errors"
+	"github.com/boasihq/interactive-inputs/internal/fields"
+	"github.com/boasihq/interactive-inputs/internal/live"
+	"github.com/boasihq/interactive-inputs/internal/membership"
+	"github.com/boasihq/interactive-inputs/internal/result"
+	"github.com/boasihq/interactive-inputs/internal/submission"
+	"go.uber.org/zap"
+)
+
+// DecisionSettings holds how the portal is approved or rejected in decision mode
+type DecisionSettings struct {
+
+	// ReasonRequiredToApprove is true when a reason must be given to approve the portal
+	ReasonRequiredToApprove bool
+
+	// ReasonRequiredToReject is true when a reason must be given to reject the portal
+	ReasonRequiredToReject bool
+
+	// FailOnReject is true when the step should fail once the portal is rejected, otherwise
+	// the step succeeds so the workflow can branch on the decision output
+	FailOnReject bool
+
+	// Quorum collects the approvals needed before the portal completes, the first approval
+	// completes the portal when nil
+	Quorum *approval.Quorum
+}
+
+// reasonRequired returns whether a reason must be given to make the decision
+func (s *DecisionSettings) reasonRequired(decision string) bool {
+	if decision == DecisionApproved {
+		return s.ReasonRequiredToApprove
+	}
+
+	return s.ReasonRequiredToReject
+}
+
+// DecidePortal returns response for request to approve or reject the portal. Approving
+// validates the submitted values and records the approval, with the outputs of the values
+// set in the same way as a submission once enough approvals have been given. Rejecting
+// ignores the values and vetoes the request straight away. Either way, the decision and
+// the reason given for it are set as outputs once the request is decided.
+func (h *Handler) DecidePortal(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+
+	decision := r.Form.Get(DecisionFormKey)
+	reason := strings.TrimSpace(r.Form.Get(DecisionReasonFormKey))
+
+	if h.decision == nil || (decision != DecisionApproved && decision != DecisionRejected) {
+		h.actionPkg.Errorf("Decision missing or not expected in request: '%s'", decision)
+
+		//nolint will set up default fallback later
+		getBaseResponseHandler().NewHTTPErrorResponse(w, errors.New(ErrKeyInvalidDecision))
+		return
+	}
+
+	identity, ok := h.requestIdentity(r)
+	if !ok {
+		h.actionPkg.Warningf("Decision refused, the %s header is missing from the request", h.identityHeader)
+
+		//nolint will set up default fallback later
+		getBaseResponseHandler().NewHTTPErrorResponse(w, errors.New(ErrKeyIdentityMissing))
+		return
+	}
+
+	// a repeat of the decision that completed the portal, i.e. when the button is
+	// double-clicked, is answered in the same way without being made again
+	if h.submission.IsDuplicate(h.completionAttempt(r, decisionOutcome(decision))) {
+		h.actionPkg.Debugf("Repeated decision received")
+
+		jobUrl, _ := h.runUrl()
+		h.writeResponseTemplate(w, "decision.tmpl.html", map[string]interface{}{
+			"JobUrl":   jobUrl,
+			"Decision": decision,
+			"Reason":   reason,
+			"Status":   h.decision.Quorum.Status(),
+		})
+		return
+	}
+
+	// only members of the approver teams can approve or veto the request
+	var teams []string
+	if approverTeams := h.decision.Quorum.ApproverTeams(); len(approverTeams) > 0 {
+		var err error
+		teams, err = membership.MemberTeams(h.membershipChecker, approverTeams, identity)
+		if err != nil {
+			h.actionPkg.Errorf("Unable to check the approver teams of %s: %v", identity, err)
+
+			//nolint will set up default fallback later
+			getBaseResponseHandler().NewHTTPErrorResponse(w, errors.New(ErrKeyUnableToCheckTeamMembership))
+			return
+		}
+
+		if len(teams) == 0 {
+			h.actionPkg.Warningf("Decision refused, %s isn't a member of any of the approver teams", identity)
+
+			//nolint will set up default fallback later
+			getBaseResponseHandler().NewHTTPErrorResponse(w, errors.New(ErrKeyNotPermittedToDecide))
+			return
+		}
+	}
+
+	// the decision isn't a field, so it is removed before the submitted values are used
+	r.Form.Del(DecisionFormKey)
+	r.Form.Del(DecisionReasonFormKey)
+
+	var fieldErrors fields.FieldErrors = make(fields.FieldErrors)
+	if decision == DecisionApproved {
+		permissionErrors, ok := h.enforcePermissions(w, r.Form, identity)
+		if !ok {
+			return
+		}
+
+		fieldErrors = h.validateForm(r.Form, "").Merge(permissionErrors)
+	}
+
+	if reason == "" && h.decision.reasonRequired(decision) {
+		fieldErrors[DecisionReasonFormKey] = fmt.Sprintf("A reason must be given when the request is %s", decision)
+	}
+
+	if len(fieldErrors) > 0 {
+		h.actionPkg.Warningf("Decision refused, %d field(s) contain invalid values", len(fieldErrors))
+		h.writeFieldErrorsResponse(w, fieldErrors)
+		return
+	}
+
+	var err error
+	var decided bool = true
+	if decision == DecisionApproved {
+		decided, err = h.decision.Quorum.Approve(approval.Approval{Identity: identity, Teams: teams, Reason: reason})
+	}
+	if decision == DecisionRejected {
+		err = h.decision.Quorum.Veto()
+	}
+
+	if err != nil {
+		h.actionPkg.Warningf("Decision refused from %s: %v", identity, err)
+
+		errKey := ErrKeyAlreadyDecided
+		if err == internalerrors.ErrAlreadyApproved {
+			errKey = ErrKeyAlreadyApproved
+		}
+
+		//nolint will set up default fallback later
+		getBaseResponseHandler().NewHTTPErrorResponse(w, errors.New(errKey))
+		return
+	}
+
+	status := h.decision.Quorum.Status()
+	jobUrl, runId := h.runUrl()
+
+	// the portal stays open until enough approvals have been given
+	if !decided {
+		h.actionPkg.Infof("Approval %d of %d received from %s", len(status.Approvals), status.RequiredApprovals, identity)
+		h.writeResponseTemplate(w, "approval.tmpl.html", map[string]interface{}{
+			"JobUrl":   jobUrl,
+			"Identity": identity,
+			"Status":   status,
+		})
+		return
+	}
+
+	// only the first attempt to complete the portal wins, so the outputs of the decision
+	// aren't set once it has been completed in another way
+	completion, err := h.submission.Complete(h.completionAttempt(r, decisionOutcome(decision)), func() error {
+		if h.isRunningLocal {
+			h.actionPkg.Infof("Running locally, will only print the form data to stdout")
+		}
+
+		var outputs []fields.Output
+		if decision == DecisionApproved {
+			var err error
+			outputs, err = h.getOutputs(r.Form)
+			if err != nil {
+				return err
+			}
+		}
+
+		outputs = append(outputs,
+			fields.Output{Label: DecisionFormKey, Value: decision},
+			fields.Output{Label: DecisionReasonFormKey, Value: reason},
+			fields.Output{Label: ApproversOutput, Value: strings.Join(status.Approvers(), ",")},
+		)
+
+		for _, output := range outputs {
+
+			// secrets are masked before they can be logged
+			if output.Secret && output.Value != "" {
+				h.actionPkg.AddMask(output.Value)
+			}
+
+			h.actionPkg.Infof("%s: %s", output.Label, output.Value)
+
+			if !h.isRunningLocal {
+				// Can't use when running locally
+				h.actionPkg.SetOutput(output.Label, output.Value)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		h.actionPkg.Errorf("Unable to build outputs from submitted values: %v", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	// the quorum only decides once, so a completion that didn't win was made in another way
+	if completion != submission.ResultCompleted {
+		h.actionPkg.Warningf("Decision refused, the portal has already been completed")
+		h.writeAlreadySubmittedResponse(w, jobUrl)
+		return
+	}
+
+	if !h.writeResponseTemplate(w, "decision.tmpl.html", map[string]interface{}{
+		"JobUrl":   jobUrl,
+		"Decision": decision,
+		"Reason":   reason,
+		"Status":   status,
+	}) {
+		return
+	}
+
+	h.actionPkg.Infof("The request has been %s!", decision)
+
+	// an approval is made by everyone who approved, a rejection by whoever rejected
+	completedBy := identity
+	if decision == DecisionApproved {
+		completedBy = strings.Join(status.Approvers(), ", ")
+	}
+	completed := result.Result{Outcome: decisionOutcome(decision), By: completedBy, Reason: reason}
+	if decision == DecisionApproved {
+		completed.Values = h.resultValues(r.Form)
+	}
+	completed = h.completePortal(completed)
+
+	// put an exit command in background so that the action can finish, failing the
+	// step when a rejection should stop the job
+	go func() {
+		h.notifyCompleted(completed)
+
+		time.Sleep(h.exitDelay(5 * time.Second))
+
+		if decision == DecisionRejected && h.decision.FailOnReject {
+			if reason != "" {
+				h.actionPkg.Fatalf("Job within run %d rejected: %s", runId, reason)
+			}
+			h.actionPkg.Fatalf("Job within run %d rejected", runId)
+		}
+
+		os.Exit(0)
+	}()
+}
+
+// decisionOutcome returns the outcome the portal is completed with by the decision
+func decisionOutcome(decision string) string {
+	if decision == DecisionApproved {
+		return live.OutcomeApproved
+	}
+
+	return live.OutcomeRejected
+}
+
+// writeResponseTemplate writes the response template, from the responses partials, that
+// replaces the portal's form. It returns whether the template was written.
+func (h *Handler) writeResponseTemplate(w http.ResponseWriter, name string, data interface{}) bool {
+	return h.writeResponseTemplateWithStatus(w, http.StatusOK, name, data)
+}
+
+// writeResponseTemplateWithStatus writes the response template in the same way as
+// writeResponseTemplate, with the given status code
+func (h *Handler) writeResponseTemplateWithStatus(w http.ResponseWriter, statusCode int, name string, data interface{}) bool {
+
+	// Parse template
+	parsedTemplates, err := template.ParseFS(h.embeddedContent, fmt.Sprintf("%sweb/ui/html/partials/responses/%s", h.embeddedContentFilePathPrefix, name))
+	if err != nil {
+		h.actionPkg.Errorf("Unable to parse referenced template: %v", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return false
+	}
+
+	// Added templates needed for htmx replacement
+	w.Header().Set("HX-Trigger", "template-executed")
+	w.Header().Set("HX-Trigger-After-Swap", "template-swapped")
+	w.WriteHeader(statusCode)
+	w.Header().Set("Content-Type", "text/html")
+
+	// Write template to response
+	err = parsedTemplates.Execute(w, data)
+	if err != nil {
+		h.actionPkg.Errorf("Unable to execute parsed template: %v", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return false
+	}
+
+	return true
+}
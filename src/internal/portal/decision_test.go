@@ -0,0 +1,96 @@
+package portal_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/boasihq/interactive-inputs/internal/portal"
+	"github.com/sethvargo/go-githubactions"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestHandler returns a handler running locally with the given settings, serving the
+// templates of the web app
+func newTestHandler(request portal.NewHandlerRequest) *portal.Handler {
+	request.ActionPkg = githubactions.New(
+		githubactions.WithWriter(bytes.NewBuffer(nil)),
+		githubactions.WithGetenv(func(key string) string {
+			return map[string]string{"GITHUB_REPOSITORY": "acme/app", "GITHUB_RUN_ID": "1"}[key]
+		}),
+	)
+	request.IsRunningLocal = true
+	request.EmbeddedContent = os.DirFS("../")
+
+	return portal.NewHandler(&request)
+}
+
+// newTestRequest returns a form submission made with the given idempotency key
+func newTestRequest(target string, form url.Values, idempotencyKey string) *http.Request {
+	request := httptest.NewRequest(http.MethodPost, target, strings.NewReader(form.Encode()))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set(portal.IdempotencyKeyHeader, idempotencyKey)
+
+	return request
+}
+
+func TestHandler_DecidePortal(t *testing.T) {
+	type attempt struct {
+		decision           string
+		idempotencyKey     string
+		expectedStatusCode int
+		expectedBody       string
+	}
+
+	tests := []struct {
+		name     string
+		attempts []attempt
+	}{
+		{
+			name: "a repeat of the approval is answered in the same way",
+			attempts: []attempt{
+				{decision: portal.DecisionApproved, idempotencyKey: "key-1", expectedStatusCode: http.StatusOK, expectedBody: "Request Approved"},
+				{decision: portal.DecisionApproved, idempotencyKey: "key-1", expectedStatusCode: http.StatusOK, expectedBody: "Request Approved"},
+			},
+		},
+		{
+			name: "a repeat of the rejection is answered in the same way",
+			attempts: []attempt{
+				{decision: portal.DecisionRejected, idempotencyKey: "key-1", expectedStatusCode: http.StatusOK, expectedBody: "Request Rejected"},
+				{decision: portal.DecisionRejected, idempotencyKey: "key-1", expectedStatusCode: http.StatusOK, expectedBody: "Request Rejected"},
+			},
+		},
+		{
+			name: "another decision is refused once the request is decided",
+			attempts: []attempt{
+				{decision: portal.DecisionApproved, idempotencyKey: "key-1", expectedStatusCode: http.StatusOK, expectedBody: "Request Approved"},
+				{decision: portal.DecisionRejected, idempotencyKey: "key-2", expectedStatusCode: http.StatusConflict, expectedBody: "already been approved or rejected"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler := newTestHandler(portal.NewHandlerRequest{
+				Decision: &portal.DecisionSettings{FailOnReject: false},
+			})
+
+			for _, attempt := range test.attempts {
+				w := httptest.NewRecorder()
+				handler.DecidePortal(w, newTestRequest("/decide", url.Values{portal.DecisionFormKey: {attempt.decision}}, attempt.idempotencyKey))
+
+				assert.Equal(t, attempt.expectedStatusCode, w.Code)
+				assert.Contains(t, w.Body.String(), attempt.expectedBody)
+			}
+
+			// the portal can't be cancelled in decision mode
+			w := httptest.NewRecorder()
+			handler.CancelPortal(w, newTestRequest("/cancel", url.Values{}, "key-1"))
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
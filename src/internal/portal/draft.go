@@ -0,0 +1,58 @@
+package portal
+
+import (
+	"errors"
+	"net/http"
+)
+
+// maxDraftSize is the largest request body, in bytes, accepted when saving a draft
+const maxDraftSize int64 = 1 << 20
+
+// SaveDraft returns response for request to save the values entered into the portal as the
+// draft of the session, so they can be restored when the portal is reloaded. Only the
+// values of fields with an output are saved.
+func (h *Handler) SaveDraft(w http.ResponseWriter, r *http.Request) {
+	if h.drafts == nil || h.fields == nil {
+		h.actionPkg.Debugf("Draft not saved, the portal has no fields whose values can be saved")
+
+		//nolint will set up default fallback later
+		getBaseResponseHandler().NewHTTPErrorResponse(w, errors.New(ErrKeyDraftsNotSaved))
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxDraftSize)
+	if err := r.ParseForm(); err != nil {
+		h.actionPkg.Warningf("Draft not saved, unable to read the values: %v", err)
+
+		//nolint will set up default fallback later
+		getBaseResponseHandler().NewHTTPErrorResponse(w, errors.New(ErrKeyInvalidDraft))
+		return
+	}
+
+	session := h.drafts.Session(r)
+	if session == "" {
+		h.actionPkg.Debugf("Draft not saved, the request isn't part of a session started by the portal")
+
+		//nolint will set up default fallback later
+		getBaseResponseHandler().NewHTTPErrorResponse(w, errors.New(ErrKeyUnknownDraftSession))
+		return
+	}
+
+	values := h.fields.DraftValues(r.PostForm)
+	saved, err := h.drafts.Save(session, values)
+	if err != nil {
+		h.actionPkg.Debugf("Draft not saved: %v", err)
+
+		//nolint will set up default fallback later
+		getBaseResponseHandler().NewHTTPErrorResponse(w, errors.New(ErrKeyUnknownDraftSession))
+		return
+	}
+
+	h.actionPkg.Debugf("Draft saved with the values of %d input(s)", len(values))
+
+	//nolint will set up default fallback later
+	getBaseResponseHandler().NewHTTPDataResponse(w, http.StatusOK, &SaveDraftResponse{
+		SavedAt: saved.SavedAt,
+		Shared:  h.drafts.Shared(),
+	})
+}
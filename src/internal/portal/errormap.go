@@ -10,6 +10,19 @@ import (
 var portalErrorMap = map[string]reply.ErrorManifestItem{
 	ErrNoFilesProvidedWithUploadRequest:  {Title: "Bad Request", Detail: "No files detected. Verify file(s) submitted with upload request", StatusCode: http.StatusBadRequest},
 	ErrKeyInvalidInputFieldId:            {Title: "Bad Request", Detail: "Target input field id (label) missing or malformatted", StatusCode: http.StatusBadRequest},
+	ErrKeyInvalidStepId:                  {Title: "Bad Request", Detail: "Target step id (label) missing or malformatted", StatusCode: http.StatusBadRequest},
+	ErrKeyInvalidDecision:                {Title: "Bad Request", Detail: "Decision missing or not expected by the portal", StatusCode: http.StatusBadRequest},
+	ErrKeyNotAForm:                       {Title: "Bad Request", Detail: "The portal must be decided or acknowledged rather than submitted or cancelled", StatusCode: http.StatusBadRequest},
+	ErrKeyNotAGate:                       {Title: "Bad Request", Detail: "The portal isn't a gate that can be acknowledged", StatusCode: http.StatusBadRequest},
+	ErrKeyAlreadyAcknowledged:            {Title: "Conflict", Detail: "The gate has already been acknowledged", StatusCode: http.StatusConflict},
+	ErrKeyDraftsNotSaved:                 {Title: "Bad Request", Detail: "The portal doesn't save drafts", StatusCode: http.StatusBadRequest},
+	ErrKeyInvalidDraft:                   {Title: "Bad Request", Detail: "The draft is missing or too large to be saved", StatusCode: http.StatusBadRequest},
+	ErrKeyUnknownDraftSession:            {Title: "Bad Request", Detail: "The draft isn't part of a session started by the portal, reload the portal to start one", StatusCode: http.StatusBadRequest},
+	ErrKeyIdentityMissing:                {Title: "Unauthorized", Detail: "Unable to identify who is making the request", StatusCode: http.StatusUnauthorized},
+	ErrKeyNotPermittedToDecide:           {Title: "Forbidden", Detail: "Only members of the approver teams can approve or reject the request", StatusCode: http.StatusForbidden},
+	ErrKeyAlreadyApproved:                {Title: "Conflict", Detail: "You have already approved the request", StatusCode: http.StatusConflict},
+	ErrKeyAlreadyDecided:                 {Title: "Conflict", Detail: "The request has already been approved or rejected", StatusCode: http.StatusConflict},
+	ErrKeyUnableToCheckTeamMembership:    {Title: "Bad Gateway", Detail: "Unable to check the GitHub teams you are a member of", StatusCode: http.StatusBadGateway},
 	ErrKeyNoInputFieldCacheDirFound:      {Title: "Bad Request", Detail: "No cache directory found for input field label", StatusCode: http.StatusBadRequest},
 	ErrKeyUnableToReadCacheDir:           {Title: "Internal Server Error", Detail: "Unable to read cache directory", StatusCode: http.StatusInternalServerError},
 	ErrKeyUnableToRemoveCacheDirContents: {Title: "Internal Server Error", Detail: "Unable to remove cache directory content(s)", StatusCode: http.StatusInternalServerError},
@@ -0,0 +1,80 @@
+package portal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/boasihq/interactive-inputs/internal/live"
+	"go.uber.org/zap"
+)
+
+// eventsRefreshInterval is how often the state of the portal is sent to viewers when it
+// hasn't changed, so the remaining time stays accurate and proxies keep the stream open
+const eventsRefreshInterval time.Duration = 15 * time.Second
+
+// StreamEvents returns response for request to follow the live state of the portal as
+// Server-Sent Events. The viewer is counted as present for as long as the stream is open,
+// and is sent the state of the portal each time it changes: who is viewing it, whether it
+// is locked, the time remaining and, once it has been completed, the outcome.
+func (h *Handler) StreamEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok || h.live == nil {
+		h.actionPkg.Errorf("Unable to stream the live state of the portal")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	// viewers don't have to be identified, they are only named when they are
+	identity, _ := h.requestIdentity(r)
+
+	viewerId, updates := h.live.Join(identity)
+	defer h.live.Leave(viewerId)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	ticker := time.NewTicker(eventsRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := h.writeStateEvent(w); err != nil {
+			h.actionPkg.Debugf("Stopped streaming the live state of the portal: %v", err)
+			return
+		}
+		flusher.Flush()
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-updates:
+		case <-ticker.C:
+		}
+	}
+}
+
+// writeStateEvent writes the current state of the portal as a Server-Sent Event
+func (h *Handler) writeStateEvent(w http.ResponseWriter) error {
+	state, err := json.Marshal(h.live.State())
+	if err != nil {
+		h.actionPkg.Errorf("Unable to marshal the live state of the portal: %v", zap.Error(err))
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", StateEventName, state)
+
+	return err
+}
+
+// completeLive tells the viewers of the portal how, and by whom, it was completed
+func (h *Handler) completeLive(kind, identity string) {
+	if h.live == nil {
+		return
+	}
+
+	h.live.Complete(live.Outcome{Kind: kind, By: identity})
+}
@@ -0,0 +1,167 @@
+package portal
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/boasihq/interactive-inputs/internal/fields"
+	"github.com/boasihq/interactive-inputs/internal/live"
+	"github.com/boasihq/interactive-inputs/internal/notifier"
+	"github.com/boasihq/interactive-inputs/internal/result"
+	"github.com/boasihq/interactive-inputs/internal/submission"
+	"github.com/boasihq/interactive-inputs/internal/toolbox"
+)
+
+// GateSettings holds how the portal is acknowledged in gate mode
+type GateSettings struct {
+
+	// Checklist are the items that must all be ticked before the gate is acknowledged
+	Checklist []string
+
+	// Notifiers are told who acknowledged the gate, and when
+	Notifiers []notifier.Notifier
+
+	// NotifierTitle is the title the notifications are sent with
+	NotifierTitle string
+
+	// acknowledgedAt is when the gate was acknowledged, set while the portal is completed
+	// so it is guarded by the submission guard
+	acknowledgedAt time.Time
+}
+
+// AcknowledgePortal returns response for request to acknowledge the portal in gate mode.
+// Every item of the checklist must have been ticked, and who acknowledged the gate, along
+// with when, are set as outputs and sent to the notifiers.
+func (h *Handler) AcknowledgePortal(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+
+	if h.gate == nil {
+		h.actionPkg.Errorf("Acknowledgement not expected, the portal isn't a gate")
+
+		//nolint will set up default fallback later
+		getBaseResponseHandler().NewHTTPErrorResponse(w, errors.New(ErrKeyNotAGate))
+		return
+	}
+
+	identity, ok := h.requestIdentity(r)
+	if !ok {
+		h.actionPkg.Warningf("Acknowledgement refused, the %s header is missing from the request", h.identityHeader)
+
+		//nolint will set up default fallback later
+		getBaseResponseHandler().NewHTTPErrorResponse(w, errors.New(ErrKeyIdentityMissing))
+		return
+	}
+
+	// each checklist item is ticked by submitting its position
+	for i := range h.gate.Checklist {
+		if !toolbox.StringInSlice(strconv.Itoa(i), r.Form[ChecklistFormKey]) {
+			h.actionPkg.Warningf("Acknowledgement refused, checklist item %d isn't ticked", i+1)
+			h.writeFieldErrorsResponse(w, fields.FieldErrors{ChecklistFormKey: "Every item of the checklist must be ticked"})
+			return
+		}
+	}
+
+	acknowledgedAt := time.Now().UTC()
+	jobUrl, _ := h.runUrl()
+
+	// only the first attempt to complete the portal wins, so the gate can't be acknowledged
+	// once it has been cancelled, or acknowledged again
+	completion, _ := h.submission.Complete(h.completionAttempt(r, live.OutcomeAcknowledged), func() error {
+		h.gate.acknowledgedAt = acknowledgedAt
+
+		if h.isRunningLocal {
+			h.actionPkg.Infof("Running locally, will only print the form data to stdout")
+		}
+
+		for _, output := range []fields.Output{
+			{Label: AcknowledgedByOutput, Value: identity},
+			{Label: AcknowledgedAtOutput, Value: acknowledgedAt.Format(time.RFC3339)},
+		} {
+
+			h.actionPkg.Infof("%s: %s", output.Label, output.Value)
+
+			if !h.isRunningLocal {
+				// Can't use when running locally
+				h.actionPkg.SetOutput(output.Label, output.Value)
+			}
+		}
+
+		return nil
+	})
+
+	switch completion {
+	case submission.ResultDuplicate:
+
+		// a repeat of the acknowledgement, i.e. when the button is double-clicked, is
+		// answered in the same way without acknowledging again
+		h.actionPkg.Debugf("Repeated acknowledgement received")
+		h.writeResponseTemplate(w, "acknowledged.tmpl.html", map[string]interface{}{
+			"JobUrl":         jobUrl,
+			"Identity":       identity,
+			"AcknowledgedAt": h.gate.acknowledgedAt,
+		})
+		return
+
+	case submission.ResultAlreadyCompleted:
+		if winner, _ := h.submission.Winner(); winner.Kind == live.OutcomeAcknowledged {
+			h.actionPkg.Warningf("Acknowledgement refused, the gate has already been acknowledged")
+
+			//nolint will set up default fallback later
+			getBaseResponseHandler().NewHTTPErrorResponse(w, errors.New(ErrKeyAlreadyAcknowledged))
+			return
+		}
+
+		h.actionPkg.Warningf("Acknowledgement refused, the portal has already been completed")
+		h.writeAlreadySubmittedResponse(w, jobUrl)
+		return
+	}
+
+	if !h.writeResponseTemplate(w, "acknowledged.tmpl.html", map[string]interface{}{
+		"JobUrl":         jobUrl,
+		"Identity":       identity,
+		"AcknowledgedAt": acknowledgedAt,
+	}) {
+		return
+	}
+
+	h.actionPkg.Infof("The gate has been acknowledged!")
+	h.completePortal(result.Result{Outcome: live.OutcomeAcknowledged, By: identity, At: acknowledgedAt})
+
+	// put an exit command in background so that the action can finish, once the
+	// notifiers have been told about the acknowledgement
+	go func() {
+		h.notifyGateAcknowledged(identity, acknowledgedAt)
+
+		time.Sleep(h.exitDelay(5 * time.Second))
+		os.Exit(0)
+	}()
+}
+
+// notifyGateAcknowledged tells the enabled notifiers who acknowledged the gate, and when
+func (h *Handler) notifyGateAcknowledged(identity string, acknowledgedAt time.Time) {
+	message := fmt.Sprintf("Acknowledged at %s", acknowledgedAt.Format("2006-01-02 15:04 UTC"))
+	if identity != "" {
+		message = fmt.Sprintf("Acknowledged by %s at %s", identity, acknowledgedAt.Format("2006-01-02 15:04 UTC"))
+	}
+
+	for _, gateNotifier := range h.gate.Notifiers {
+		if !gateNotifier.Enabled() {
+			continue
+		}
+
+		// the acknowledgement links to where its result can be found, rather than being
+		// followed by another notification
+		gateMessage := message
+		if link := h.resultLink(gateNotifier); link != "" {
+			gateMessage = message + "\n\n" + link
+		}
+
+		if _, err := gateNotifier.Notify(notifier.HeadingGateAcknowledged, h.gate.NotifierTitle, gateMessage); err != nil {
+			h.actionPkg.Errorf("Notifier Notification Failed: %v", err)
+		}
+	}
+}
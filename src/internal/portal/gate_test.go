@@ -0,0 +1,68 @@
+package portal_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/boasihq/interactive-inputs/internal/portal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_AcknowledgePortal(t *testing.T) {
+	type attempt struct {
+		cancel             bool
+		idempotencyKey     string
+		expectedStatusCode int
+		expectedBody       string
+	}
+
+	tests := []struct {
+		name     string
+		attempts []attempt
+	}{
+		{
+			name: "a repeat of the acknowledgement is answered in the same way",
+			attempts: []attempt{
+				{idempotencyKey: "key-1", expectedStatusCode: http.StatusOK, expectedBody: "Gate Acknowledged"},
+				{idempotencyKey: "key-1", expectedStatusCode: http.StatusOK, expectedBody: "Gate Acknowledged"},
+			},
+		},
+		{
+			name: "another acknowledgement is refused",
+			attempts: []attempt{
+				{idempotencyKey: "key-1", expectedStatusCode: http.StatusOK, expectedBody: "Gate Acknowledged"},
+				{idempotencyKey: "key-2", expectedStatusCode: http.StatusConflict, expectedBody: "already been acknowledged"},
+			},
+		},
+		{
+			name: "the gate can't be cancelled",
+			attempts: []attempt{
+				{cancel: true, idempotencyKey: "key-1", expectedStatusCode: http.StatusBadRequest, expectedBody: "rather than submitted or cancelled"},
+				{idempotencyKey: "key-2", expectedStatusCode: http.StatusOK, expectedBody: "Gate Acknowledged"},
+				{cancel: true, idempotencyKey: "key-2", expectedStatusCode: http.StatusBadRequest, expectedBody: "rather than submitted or cancelled"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler := newTestHandler(portal.NewHandlerRequest{
+				Gate: &portal.GateSettings{Checklist: []string{"Backups taken"}},
+			})
+
+			for _, attempt := range test.attempts {
+				w := httptest.NewRecorder()
+				if attempt.cancel {
+					handler.CancelPortal(w, newTestRequest("/cancel", url.Values{}, attempt.idempotencyKey))
+				} else {
+					handler.AcknowledgePortal(w, newTestRequest("/acknowledge", url.Values{portal.ChecklistFormKey: {"0"}}, attempt.idempotencyKey))
+				}
+
+				assert.Equal(t, attempt.expectedStatusCode, w.Code)
+				assert.Contains(t, w.Body.String(), attempt.expectedBody)
+			}
+		})
+	}
+}
@@ -1,17 +1,27 @@
 package portal
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/fs"
 	"net/http"
+	"net/url"
 	"os"
 	"path"
+	"sort"
 	"strings"
 	"text/template"
 	"time"
 
+	"github.com/boasihq/interactive-inputs/internal/approval"
+	"github.com/boasihq/interactive-inputs/internal/draft"
+	"github.com/boasihq/interactive-inputs/internal/fields"
+	"github.com/boasihq/interactive-inputs/internal/live"
+	"github.com/boasihq/interactive-inputs/internal/membership"
+	"github.com/boasihq/interactive-inputs/internal/result"
+	"github.com/boasihq/interactive-inputs/internal/submission"
 	"github.com/gorilla/mux"
 	"github.com/ooaklee/reply"
 	"github.com/sethvargo/go-githubactions"
@@ -27,6 +37,8 @@ type actionPkg interface {
 	Errorf(msg string, args ...any)
 	Fatalf(msg string, args ...any)
 	SetOutput(k string, v string)
+	AddMask(p string)
+	AddStepSummary(markdown string)
 }
 
 // Handler manages portal requests
@@ -49,23 +61,123 @@ type Handler struct {
 
 	// inputFieldLabelToCacheDirMapping mapping of input field label to its cache directory
 	inputFieldLabelToCacheDirMapping map[string]string
+
+	// fields the fields displayed in the portal, used to validate submitted values
+	fields *fields.Fields
+
+	// decision how the portal is approved or rejected, nil unless in decision mode
+	decision *DecisionSettings
+
+	// gate how the portal is acknowledged, nil unless in gate mode
+	gate *GateSettings
+
+	// identityHeader the request header identifying who is using the portal, set by a
+	// trusted proxy. Requests aren't identified when empty
+	identityHeader string
+
+	// membershipChecker checks the GitHub teams the identity is a member of
+	membershipChecker membership.Checker
+
+	// drafts holds the values entered into the portal that haven't been submitted yet
+	drafts *draft.Store
+
+	// live keeps track of who is viewing the portal and how it was completed
+	live *live.Hub
+
+	// submission makes sure the portal is only submitted, or cancelled, once
+	submission *submission.Guard
+
+	// result how the result of the portal is shared once it has been completed
+	result *ResultSettings
+}
+
+// NewHandlerRequest holds everything needed to create a portal handler
+type NewHandlerRequest struct {
+
+	// ActionPkg represents the githubactions package
+	ActionPkg actionPkg
+
+	// IsRunningLocal is true when running locally
+	IsRunningLocal bool
+
+	// EmbeddedContent embedded content of the web app
+	EmbeddedContent fs.FS
+
+	// EmbeddedContentFilePathPrefix path prefix of the embedded content
+	EmbeddedContentFilePathPrefix string
+
+	// GithubToken is the github token used to make Api calls
+	GithubToken string
+
+	// InputFieldLabelToCacheDirMapping mapping of input field label to its cache directory
+	InputFieldLabelToCacheDirMapping map[string]string
+
+	// Fields the fields displayed in the portal
+	Fields *fields.Fields
+
+	// Decision how the portal is approved or rejected, nil unless in decision mode
+	Decision *DecisionSettings
+
+	// Gate how the portal is acknowledged, nil unless in gate mode
+	Gate *GateSettings
+
+	// IdentityHeader the request header identifying who is using the portal, set by a
+	// trusted proxy. Requests aren't identified when empty
+	IdentityHeader string
+
+	// MembershipChecker checks the GitHub teams the identity is a member of
+	MembershipChecker membership.Checker
+
+	// Drafts holds the values entered into the portal that haven't been submitted yet
+	Drafts *draft.Store
+
+	// Live keeps track of who is viewing the portal and how it was completed
+	Live *live.Hub
+
+	// Result how the result of the portal is shared once it has been completed
+	Result *ResultSettings
 }
 
 // NewHandler returns portal handler
-func NewHandler(actionPkg actionPkg, isRunningLocal bool, embeddedContent fs.FS, embeddedContentFilePathPrefix, githubToken string, inputFieldLabelToCacheDirMapping map[string]string) *Handler {
+func NewHandler(r *NewHandlerRequest) *Handler {
+
+	// a single approval completes the portal unless a quorum is provided
+	if r.Decision != nil && r.Decision.Quorum == nil {
+		r.Decision.Quorum = approval.NewQuorum(&approval.NewQuorumRequest{})
+	}
+
 	return &Handler{
-		isRunningLocal:                   isRunningLocal,
-		actionPkg:                        actionPkg,
-		embeddedContent:                  embeddedContent,
-		embeddedContentFilePathPrefix:    embeddedContentFilePathPrefix,
-		githubToken:                      githubToken,
-		inputFieldLabelToCacheDirMapping: inputFieldLabelToCacheDirMapping,
+		isRunningLocal:                   r.IsRunningLocal,
+		actionPkg:                        r.ActionPkg,
+		embeddedContent:                  r.EmbeddedContent,
+		embeddedContentFilePathPrefix:    r.EmbeddedContentFilePathPrefix,
+		githubToken:                      r.GithubToken,
+		inputFieldLabelToCacheDirMapping: r.InputFieldLabelToCacheDirMapping,
+		fields:                           r.Fields,
+		decision:                         r.Decision,
+		gate:                             r.Gate,
+		identityHeader:                   r.IdentityHeader,
+		membershipChecker:                r.MembershipChecker,
+		drafts:                           r.Drafts,
+		live:                             r.Live,
+		submission:                       submission.NewGuard(),
+		result:                           r.Result,
 	}
 }
 
 // CancelPortal returns response for request to cancel the portal
 func (h *Handler) CancelPortal(w http.ResponseWriter, r *http.Request) {
 
+	// decision mode is completed by rejecting, which checks who is deciding and why, and a
+	// gate can only be acknowledged, so neither can be skipped by cancelling
+	if h.decision != nil || h.gate != nil {
+		h.actionPkg.Errorf("Cancellation not expected, the portal must be decided or acknowledged")
+
+		//nolint will set up default fallback later
+		getBaseResponseHandler().NewHTTPErrorResponse(w, errors.New(ErrKeyNotAForm))
+		return
+	}
+
 	additionalContext := map[string]string{
 		"JobUrl": "",
 	}
@@ -86,6 +198,15 @@ func (h *Handler) CancelPortal(w http.ResponseWriter, r *http.Request) {
 		actionContext.RunID,
 	)
 
+	// only the first attempt to complete the portal wins, so it can't be cancelled once
+	// it has been submitted
+	completion, _ := h.submission.Complete(h.completionAttempt(r, live.OutcomeCancelled), func() error { return nil })
+	if completion == submission.ResultAlreadyCompleted {
+		h.actionPkg.Warningf("Cancellation refused, the portal has already been completed")
+		h.writeAlreadySubmittedResponse(w, additionalContext["JobUrl"])
+		return
+	}
+
 	// Parse template
 	parsedTemplates, err := template.ParseFS(h.embeddedContent, fmt.Sprintf("%sweb/ui/html/partials/responses/cancel.tmpl.html", h.embeddedContentFilePathPrefix))
 	if err != nil {
@@ -108,13 +229,24 @@ func (h *Handler) CancelPortal(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// a repeat of the cancellation is answered in the same way, without cancelling again
+	if completion == submission.ResultDuplicate {
+		h.actionPkg.Debugf("Repeated cancel request received")
+		return
+	}
+
 	h.actionPkg.Infof("Cancel request received")
 
+	// the canceller is named to the other viewers when they can be identified
+	identity, _ := h.requestIdentity(r)
+	completed := h.completePortal(result.Result{Outcome: live.OutcomeCancelled, By: identity})
+
 	go func(actionContext *githubactions.GitHubContext) {
 
 		runId := actionContext.RunID
 		h.actionPkg.Infof("Cancelling job within run %d", runId)
-		time.Sleep(3 * time.Second)
+		h.notifyCompleted(completed)
+		time.Sleep(h.exitDelay(3 * time.Second))
 
 		h.actionPkg.Fatalf("Job within run %d cancelled", runId)
 
@@ -129,31 +261,35 @@ func (h *Handler) SubmitPortal(w http.ResponseWriter, r *http.Request) {
 		"JobUrl": "",
 	}
 
-	if h.isRunningLocal {
-		h.actionPkg.Infof("Running locally, will only print the form data to stdout")
-	}
-
-	for key, value := range r.Form {
+	// decision and gate modes have their own outputs, which can't be skipped by submitting
+	if h.decision != nil || h.gate != nil {
+		h.actionPkg.Errorf("Submission not expected, the portal must be decided or acknowledged")
 
-		// handle file/multifile inputs
-		if cacheDir := h.getInputFieldCacheDir(key); cacheDir != "" {
-
-			h.actionPkg.Infof("%s: %s", key, cacheDir)
+		//nolint will set up default fallback later
+		getBaseResponseHandler().NewHTTPErrorResponse(w, errors.New(ErrKeyNotAForm))
+		return
+	}
 
-			if !h.isRunningLocal {
-				// Can't use when running locally
-				h.actionPkg.SetOutput(key, cacheDir)
-			}
+	identity, ok := h.requestIdentity(r)
+	if !ok {
+		h.actionPkg.Warningf("Submission rejected, the %s header is missing from the request", h.identityHeader)
 
-			continue
-		}
+		//nolint will set up default fallback later
+		getBaseResponseHandler().NewHTTPErrorResponse(w, errors.New(ErrKeyIdentityMissing))
+		return
+	}
 
-		h.actionPkg.Infof("%s: %s", key, value)
+	permissionErrors, ok := h.enforcePermissions(w, r.Form, identity)
+	if !ok {
+		return
+	}
 
-		if !h.isRunningLocal {
-			// Can't use when running locally
-			h.actionPkg.SetOutput(key, strings.Join(value, ","))
-		}
+	// validate the submitted values before anything is set as an output, so
+	// crafted requests can't bypass the checks made by the browser
+	if fieldErrors := h.validateForm(r.Form, "").Merge(permissionErrors); len(fieldErrors) > 0 {
+		h.actionPkg.Warningf("Submission rejected, %d field(s) contain invalid values", len(fieldErrors))
+		h.writeFieldErrorsResponse(w, fieldErrors)
+		return
 	}
 
 	actionContext, err := h.actionPkg.Context()
@@ -178,6 +314,47 @@ func (h *Handler) SubmitPortal(w http.ResponseWriter, r *http.Request) {
 		// }
 	}
 
+	// only the first valid submission sets the outputs, concurrent submissions wait to
+	// find out whether it succeeded
+	completion, err := h.submission.Complete(h.completionAttempt(r, live.OutcomeSubmitted), func() error {
+		if h.isRunningLocal {
+			h.actionPkg.Infof("Running locally, will only print the form data to stdout")
+		}
+
+		outputs, err := h.getOutputs(r.Form)
+		if err != nil {
+			return err
+		}
+
+		for _, output := range outputs {
+
+			// secrets are masked before they can be logged
+			if output.Secret && output.Value != "" {
+				h.actionPkg.AddMask(output.Value)
+			}
+
+			h.actionPkg.Infof("%s: %s", output.Label, output.Value)
+
+			if !h.isRunningLocal {
+				// Can't use when running locally
+				h.actionPkg.SetOutput(output.Label, output.Value)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		h.actionPkg.Errorf("Unable to build outputs from submitted values: %v", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	if completion == submission.ResultAlreadyCompleted {
+		h.actionPkg.Warningf("Submission refused, the portal has already been completed")
+		h.writeAlreadySubmittedResponse(w, additionalContext["JobUrl"])
+		return
+	}
+
 	// Parse template
 	parsedTemplates, err := template.ParseFS(h.embeddedContent, fmt.Sprintf("%sweb/ui/html/partials/responses/success.tmpl.html", h.embeddedContentFilePathPrefix))
 	if err != nil {
@@ -200,15 +377,213 @@ func (h *Handler) SubmitPortal(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// a repeat of the submission is answered in the same way, without submitting again
+	if completion == submission.ResultDuplicate {
+		h.actionPkg.Debugf("Repeated submission received")
+		return
+	}
+
 	h.actionPkg.Infof("Your inputs have successfully been received!")
+	completed := h.completePortal(result.Result{Outcome: live.OutcomeSubmitted, By: identity, Values: h.resultValues(r.Form)})
 
-	// put an exit command in background so that the action can finish
+	// put an exit command in background so that the action can finish, once the result
+	// page has been served for its grace period
 	go func() {
-		time.Sleep(5 * time.Second)
+		h.notifyCompleted(completed)
+
+		time.Sleep(h.exitDelay(5 * time.Second))
 		os.Exit(0)
 	}()
 }
 
+// ValidateStep returns response for request to validate the values entered for a step
+// of a multi-step form before the user moves on to the next step. When valid, the
+// response includes the default values of the next step's fields that reference
+// earlier answers.
+func (h *Handler) ValidateStep(w http.ResponseWriter, r *http.Request) {
+	var stepLabel string
+
+	r.ParseForm()
+
+	if stepLabel = mux.Vars(r)[StepLabelUriVariableId]; stepLabel == "" || h.fields == nil || h.fields.StepIndex(stepLabel) == -1 {
+		h.actionPkg.Errorf("Step label missing or unknown in request: '%s'", stepLabel)
+
+		//nolint will set up default fallback later
+		getBaseResponseHandler().NewHTTPErrorResponse(w, errors.New(ErrKeyInvalidStepId))
+		return
+	}
+
+	identity, ok := h.requestIdentity(r)
+	if !ok {
+		h.actionPkg.Warningf("Step '%s' not validated, the %s header is missing from the request", stepLabel, h.identityHeader)
+
+		//nolint will set up default fallback later
+		getBaseResponseHandler().NewHTTPErrorResponse(w, errors.New(ErrKeyIdentityMissing))
+		return
+	}
+
+	// only the permission errors of the step's fields are relevant to the step
+	permissionErrors, ok := h.enforcePermissions(w, r.Form, identity)
+	if !ok {
+		return
+	}
+
+	response := ValidateStepResponse{
+		Valid:  true,
+		Errors: h.validateForm(r.Form, stepLabel),
+	}
+
+	for _, field := range h.fields.Fields {
+		if message, ok := permissionErrors[field.Label]; ok && field.Properties.Step == stepLabel {
+			response.Errors[field.Label] = message
+		}
+	}
+
+	if len(response.Errors) > 0 {
+		h.actionPkg.Debugf("Step '%s' contains %d invalid field(s)", stepLabel, len(response.Errors))
+		response.Valid = false
+
+		//nolint will set up default fallback later
+		getBaseResponseHandler().NewHTTPDataResponse(w, http.StatusUnprocessableEntity, &response)
+		return
+	}
+
+	if nextStepIndex := h.fields.StepIndex(stepLabel) + 1; nextStepIndex < len(h.fields.Steps) {
+		nextStepLabel := h.fields.Steps[nextStepIndex].Label
+		data := h.templateData(r.Form)
+
+		defaults, err := h.fields.TemplatedDefaults(nextStepLabel, data)
+		if err != nil {
+			h.actionPkg.Warningf("Unable to expand default values for step '%s': %v", nextStepLabel, err)
+		}
+
+		phrases, err := h.fields.TemplatedPhrases(nextStepLabel, data)
+		if err != nil {
+			h.actionPkg.Warningf("Unable to expand confirmation phrases for step '%s': %v", nextStepLabel, err)
+		}
+
+		response.Defaults = defaults
+		response.Phrases = phrases
+	}
+
+	//nolint will set up default fallback later
+	getBaseResponseHandler().NewHTTPDataResponse(w, http.StatusOK, &response)
+}
+
+// getOutputs returns the outputs for the submitted form values. File and multifile
+// fields output the path of the cache directory holding their uploaded files, when they
+// are submitted or files have been uploaded to them.
+func (h *Handler) getOutputs(form url.Values) ([]fields.Output, error) {
+	var outputs []fields.Output
+
+	if h.fields == nil {
+		// sorted so the outputs are always set in the same order
+		keys := make([]string, 0, len(form))
+		for key := range form {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			outputs = append(outputs, fields.Output{Label: key, Value: strings.Join(form[key], ",")})
+		}
+
+		return outputs, nil
+	}
+
+	outputs, err := h.fields.Outputs(form)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range h.fields.Fields {
+		cacheDir := h.getInputFieldCacheDir(field.Label)
+		if cacheDir == "" {
+			continue
+		}
+
+		if _, submitted := form[field.Label]; submitted || len(h.uploadedFiles(field.Label)) > 0 {
+			outputs = append(outputs, fields.Output{Label: field.Label, Value: cacheDir})
+		}
+	}
+
+	return outputs, nil
+}
+
+// validateForm validates the submitted form values for the given step (or all fields
+// when the step is empty)
+func (h *Handler) validateForm(form url.Values, stepLabel string) fields.FieldErrors {
+	if h.fields == nil {
+		return fields.FieldErrors{}
+	}
+
+	return h.fields.Validate(form, stepLabel, h.templateData(form))
+}
+
+// enforcePermissions checks the submitted form values against the teams the fields, and
+// their choices, are limited to, giving read-only fields, and the fields the identity
+// can't edit, their default values. It returns the values the identity isn't permitted to submit as field errors,
+// and false when the teams of the identity can't be checked, in which case the error
+// response has been written.
+func (h *Handler) enforcePermissions(w http.ResponseWriter, form url.Values, identity string) (fields.FieldErrors, bool) {
+	if h.fields == nil {
+		return fields.FieldErrors{}, true
+	}
+
+	// read-only fields can't be changed by anyone, whatever is submitted for them
+	h.fields.EnforceReadOnly(form, h.templateData(form))
+
+	if !h.fields.HasPermissions() {
+		return fields.FieldErrors{}, true
+	}
+
+	fieldErrors, err := h.fields.EnforcePermissions(form, h.templateData(form), func(teams []string) (bool, error) {
+		return membership.IsMemberOfAny(h.membershipChecker, teams, identity)
+	})
+	if err != nil {
+		h.actionPkg.Errorf("Unable to check the field permissions of %s: %v", identity, err)
+
+		//nolint will set up default fallback later
+		getBaseResponseHandler().NewHTTPErrorResponse(w, errors.New(ErrKeyUnableToCheckTeamMembership))
+		return nil, false
+	}
+
+	return fieldErrors, true
+}
+
+// templateData returns the data templated field properties are expanded with, made up
+// of the submitted form values and the GitHub context
+func (h *Handler) templateData(form url.Values) fields.TemplateData {
+	actionContext, err := h.actionPkg.Context()
+	if err != nil {
+		h.actionPkg.Warningf("Unable to get action context for templated properties: %v", err)
+	}
+
+	return fields.TemplateData{
+		Answers: h.fields.Answers(form),
+		GitHub:  actionContext,
+		Env:     fields.Environment(),
+	}
+}
+
+// writeFieldErrorsResponse responds to a submission that contains invalid values. The
+// errors are passed to the portal with an htmx event so they can be shown inline.
+func (h *Handler) writeFieldErrorsResponse(w http.ResponseWriter, fieldErrors fields.FieldErrors) {
+	event, err := json.Marshal(map[string]interface{}{
+		FieldsInvalidEventName: map[string]interface{}{
+			"errors": fieldErrors,
+		},
+	})
+	if err != nil {
+		h.actionPkg.Errorf("Unable to marshal field errors: %v", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("HX-Trigger", string(event))
+	http.Error(w, "Some of the provided values are invalid", http.StatusUnprocessableEntity)
+}
+
 // UploadToPortal returns response for request to upload file(s) to portal
 // for later use
 func (h *Handler) UploadToPortal(w http.ResponseWriter, r *http.Request) {
@@ -503,6 +878,38 @@ func (h *Handler) getInputFieldCacheDir(inputFieldName string) string {
 	return h.inputFieldLabelToCacheDirMapping[inputFieldName]
 }
 
+// requestIdentity returns who is making the request, as set in the identity header by the
+// trusted proxy in front of the portal, and whether they could be identified. Requests are
+// always identified when no identity header is provided, with an empty identity.
+func (h *Handler) requestIdentity(r *http.Request) (string, bool) {
+	if h.identityHeader == "" {
+		return "", true
+	}
+
+	identity := strings.TrimSpace(r.Header.Get(h.identityHeader))
+
+	return identity, identity != ""
+}
+
+// runUrl returns the url of the workflow run the portal belongs to and the run's id, or
+// an empty url if the action context is unavailable
+func (h *Handler) runUrl() (string, int64) {
+	actionContext, err := h.actionPkg.Context()
+	if err != nil {
+		h.actionPkg.Errorf("Unable to get action context: %v", zap.Error(err))
+		return "", 0
+	}
+
+	repoOwner, repoName := actionContext.Repo()
+
+	return fmt.Sprintf(
+		"%s/%s/actions/runs/%d",
+		actionContext.ServerURL,
+		repoOwner+"/"+repoName,
+		actionContext.RunID,
+	), actionContext.RunID
+}
+
 // getBaseResponseHandler returns response handler configured with respective error map
 func getBaseResponseHandler() *reply.Replier {
 	return reply.NewReplier(append([]reply.ErrorManifest{}, portalErrorMap))
@@ -1,5 +1,7 @@
 package portal
 
+import "time"
+
 // UploadToPortalResponse represents the response for uploading files to the portal
 type UploadToPortalResponse struct {
 
@@ -30,3 +32,29 @@ type ResetUploadResponse struct {
 	// TotalFilesDeleted represents the total number of files that were deleted
 	TotalFilesDeleted int `json:"total_files_deleted"`
 }
+
+// ValidateStepResponse represents the response for validating a step of the portal
+type ValidateStepResponse struct {
+	// Valid represents whether all values provided for the step are valid
+	Valid bool `json:"valid"`
+
+	// Errors represents the problems found with the provided values, keyed by field label
+	Errors map[string]string `json:"errors,omitempty"`
+
+	// Defaults represents the default values of the next step's fields that are
+	// based on earlier answers, keyed by field label
+	Defaults map[string]string `json:"defaults,omitempty"`
+
+	// Phrases represents the confirmation phrases of the next step's confirm fields
+	// that are based on earlier answers, keyed by field label
+	Phrases map[string]string `json:"phrases,omitempty"`
+}
+
+// SaveDraftResponse represents the response for saving the draft of the portal
+type SaveDraftResponse struct {
+	// SavedAt represents when the draft was saved
+	SavedAt time.Time `json:"saved_at"`
+
+	// Shared represents whether the draft is shared by everyone filling in the portal
+	Shared bool `json:"shared"`
+}
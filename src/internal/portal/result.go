@@ -0,0 +1,153 @@
+package portal
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/boasihq/interactive-inputs/internal/fields"
+	"github.com/boasihq/interactive-inputs/internal/notifier"
+	"github.com/boasihq/interactive-inputs/internal/result"
+)
+
+// ResultSettings holds how the result of the portal is shared once it has been completed
+type ResultSettings struct {
+
+	// GracePeriod is how long the read-only result page is served once the portal has been
+	// completed. The action finishes straight away when zero
+	GracePeriod time.Duration
+
+	// JobSummary is true when the result is written to the job summary
+	JobSummary bool
+
+	// PortalUrl is the url the portal is reachable at, which serves the result page once
+	// the portal has been completed
+	PortalUrl string
+
+	// Notifiers are sent a link to the result once the portal has been completed
+	Notifiers []notifier.Notifier
+
+	// Title is the title of the portal, as it is displayed
+	Title string
+
+	// Results holds the result of the portal, shown by the result page
+	Results *result.Store
+}
+
+// completePortal tells the viewers of the portal how, and by whom, it was completed and
+// keeps the result so it can be shown once the portal no longer accepts responses, writing
+// it to the job summary when enabled. It returns the result as it was kept.
+func (h *Handler) completePortal(completed result.Result) result.Result {
+	h.completeLive(completed.Outcome, completed.By)
+
+	if h.result == nil || h.result.Results == nil {
+		return completed
+	}
+
+	completed, _ = h.result.Results.Record(completed)
+
+	// the job summary can't be written to when running locally
+	if h.result.JobSummary && !h.isRunningLocal {
+		h.actionPkg.AddStepSummary(completed.Markdown(h.result.Title))
+	}
+
+	return completed
+}
+
+// resultValues returns the values set as outputs from the submitted values, with the
+// values of secret fields masked and the files uploaded to file fields listed
+func (h *Handler) resultValues(form url.Values) []fields.ReviewItem {
+	if h.fields == nil {
+		return nil
+	}
+
+	items, err := h.fields.Review(form, h.templateData(form))
+	if err != nil {
+		h.actionPkg.Warningf("Unable to keep the submitted values for the result: %v", err)
+		return nil
+	}
+
+	for i, item := range items {
+		if !item.Uploaded {
+			continue
+		}
+
+		var files []string
+		for _, file := range h.uploadedFiles(item.Label) {
+			files = append(files, fmt.Sprintf("%s (%s)", file.Name, file.Size))
+		}
+		items[i].Value = strings.Join(files, ", ")
+	}
+
+	return items
+}
+
+// resultLink returns the link to where the result of the portal can be found, formatted for
+// the notifier: the result page while it is served, otherwise the job summary. It is empty
+// when the result isn't shared.
+func (h *Handler) resultLink(resultNotifier notifier.Notifier) string {
+	if h.result == nil {
+		return ""
+	}
+
+	if h.result.GracePeriod > 0 && h.result.PortalUrl != "" {
+		return resultNotifier.Link("View the result", h.result.PortalUrl)
+	}
+
+	if h.result.JobSummary && !h.isRunningLocal {
+		if jobUrl, _ := h.runUrl(); jobUrl != "" {
+			return resultNotifier.Link("View the job summary", jobUrl)
+		}
+	}
+
+	return ""
+}
+
+// notifyCompleted tells the enabled notifiers how, and by whom, the portal was completed,
+// linking to where its result can be found. Nothing is sent when the result isn't shared.
+func (h *Handler) notifyCompleted(completed result.Result) {
+	if h.result == nil {
+		return
+	}
+
+	message := fmt.Sprintf("%s at %s", completed.OutcomeTitle(), completed.At.Format("2006-01-02 15:04 UTC"))
+	if completed.By != "" {
+		message = fmt.Sprintf("%s by %s at %s", completed.OutcomeTitle(), completed.By, completed.At.Format("2006-01-02 15:04 UTC"))
+	}
+
+	for _, resultNotifier := range h.result.Notifiers {
+		link := h.resultLink(resultNotifier)
+		if !resultNotifier.Enabled() || link == "" {
+			continue
+		}
+
+		if _, err := resultNotifier.Notify(notifier.HeadingPortalCompleted, h.result.Title, message+"\n\n"+link); err != nil {
+			h.actionPkg.Errorf("Notifier Notification Failed: %v", err)
+		}
+	}
+}
+
+// exitDelay returns how long to wait before the action finishes: at least the minimum, so
+// the response reaches the browser, or the grace period the result page is served for.
+// The grace period is cut short so the action finishes before the portal expires.
+func (h *Handler) exitDelay(minimum time.Duration) time.Duration {
+	if h.result == nil || h.result.GracePeriod <= minimum {
+		return minimum
+	}
+
+	delay := h.result.GracePeriod
+	if h.live != nil {
+		if expiresAt := h.live.State().ExpiresAt; expiresAt != nil && time.Until(*expiresAt)-time.Second < delay {
+			delay = time.Until(*expiresAt) - time.Second
+		}
+	}
+
+	if delay < minimum {
+		return minimum
+	}
+
+	h.actionPkg.Infof("Serving the result page for %s before finishing", delay.Round(time.Second))
+
+	return delay
+}
@@ -0,0 +1,141 @@
+package portal
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/boasihq/interactive-inputs/internal/fields"
+	"go.uber.org/zap"
+)
+
+// reviewFile is a file uploaded to a file field, listed when the portal is reviewed
+type reviewFile struct {
+
+	// Name is the name of the file
+	Name string
+
+	// Size is the size of the file, formatted to be read
+	Size string
+}
+
+// reviewEntry is a field shown when the portal is reviewed, along with the files uploaded
+// to it when it is a file field
+type reviewEntry struct {
+	fields.ReviewItem
+
+	// Files are the files uploaded to the field, sorted by name
+	Files []reviewFile
+}
+
+// ReviewPortal returns response for request to review the values entered into the portal
+// before they are submitted. The values are validated in the same way as a submission and
+// shown exactly as they will be set as outputs, with the values of secret fields masked,
+// the files uploaded to file fields listed with their sizes and values changed from their
+// defaults highlighted. Nothing is set as an output until the portal is submitted.
+func (h *Handler) ReviewPortal(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+
+	// only forms are submitted, so decisions and acknowledgements aren't reviewed
+	if h.decision != nil || h.gate != nil {
+		h.actionPkg.Errorf("Review not expected, the portal must be decided or acknowledged")
+
+		//nolint will set up default fallback later
+		getBaseResponseHandler().NewHTTPErrorResponse(w, errors.New(ErrKeyNotAForm))
+		return
+	}
+
+	identity, ok := h.requestIdentity(r)
+	if !ok {
+		h.actionPkg.Warningf("Review refused, the %s header is missing from the request", h.identityHeader)
+
+		//nolint will set up default fallback later
+		getBaseResponseHandler().NewHTTPErrorResponse(w, errors.New(ErrKeyIdentityMissing))
+		return
+	}
+
+	permissionErrors, ok := h.enforcePermissions(w, r.Form, identity)
+	if !ok {
+		return
+	}
+
+	if fieldErrors := h.validateForm(r.Form, "").Merge(permissionErrors); len(fieldErrors) > 0 {
+		h.actionPkg.Debugf("Review refused, %d field(s) contain invalid values", len(fieldErrors))
+		h.writeFieldErrorsResponse(w, fieldErrors)
+		return
+	}
+
+	var entries []reviewEntry
+	if h.fields != nil {
+		items, err := h.fields.Review(r.Form, h.templateData(r.Form))
+		if err != nil {
+			h.actionPkg.Errorf("Unable to build outputs from submitted values: %v", zap.Error(err))
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		for _, item := range items {
+			entry := reviewEntry{ReviewItem: item}
+			if item.Uploaded {
+				entry.Files = h.uploadedFiles(item.Label)
+				entry.Changed = len(entry.Files) > 0
+			}
+
+			entries = append(entries, entry)
+		}
+	}
+
+	h.actionPkg.Debugf("Reviewing the values of %d field(s)", len(entries))
+
+	h.writeResponseTemplate(w, "review.tmpl.html", map[string]interface{}{
+		"Entries": entries,
+	})
+}
+
+// uploadedFiles returns the files uploaded to the file field, held in its cache directory
+func (h *Handler) uploadedFiles(inputFieldLabel string) []reviewFile {
+	var files []reviewFile = []reviewFile{}
+
+	cacheDir := h.getInputFieldCacheDir(inputFieldLabel)
+	if cacheDir == "" {
+		return files
+	}
+
+	contents, err := os.ReadDir(cacheDir)
+	if err != nil {
+		h.actionPkg.Warningf("Unable to read cache directory: %s", cacheDir)
+		return files
+	}
+
+	for _, content := range contents {
+		if content.IsDir() {
+			continue
+		}
+
+		info, err := content.Info()
+		if err != nil {
+			continue
+		}
+
+		files = append(files, reviewFile{Name: content.Name(), Size: formatFileSize(info.Size())})
+	}
+
+	return files
+}
+
+// formatFileSize returns the size, given in bytes, in the largest unit it fills
+func formatFileSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	value, exponent := float64(size)/unit, 0
+	for value >= unit && exponent < 3 {
+		value /= unit
+		exponent++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", value, "KMGT"[exponent])
+}
@@ -13,9 +13,15 @@ import (
 // portalEventHandler expected methods for valid portal event handler
 type portalEventHandler interface {
 	SubmitPortal(w http.ResponseWriter, r *http.Request)
+	ReviewPortal(w http.ResponseWriter, r *http.Request)
 	CancelPortal(w http.ResponseWriter, r *http.Request)
+	DecidePortal(w http.ResponseWriter, r *http.Request)
+	AcknowledgePortal(w http.ResponseWriter, r *http.Request)
 	UploadToPortal(w http.ResponseWriter, r *http.Request)
 	ResetUpload(w http.ResponseWriter, r *http.Request)
+	ValidateStep(w http.ResponseWriter, r *http.Request)
+	SaveDraft(w http.ResponseWriter, r *http.Request)
+	StreamEvents(w http.ResponseWriter, r *http.Request)
 }
 
 // uiHandler expected methods for valid ui handler
@@ -90,10 +96,16 @@ func AttachRoutes(request *AttachRoutesRequest) {
 
     baseRouter.HandleFunc("/", request.UiHandler.Home).Methods("GET")
     baseRouter.HandleFunc("/submit", request.PortalEventHandler.SubmitPortal).Methods("POST")
+    baseRouter.HandleFunc("/review", request.PortalEventHandler.ReviewPortal).Methods("POST")
     baseRouter.HandleFunc("/cancel", request.PortalEventHandler.CancelPortal).Methods("POST")
+    baseRouter.HandleFunc("/decide", request.PortalEventHandler.DecidePortal).Methods("POST")
+    baseRouter.HandleFunc("/acknowledge", request.PortalEventHandler.AcknowledgePortal).Methods("POST")
 
     apiRouter := baseRouter.PathPrefix("/api/v1").Subrouter()
     apiRouter.HandleFunc("/upload", request.PortalEventHandler.UploadToPortal).Methods("POST", "OPTIONS")
     apiRouter.HandleFunc(fmt.Sprintf("/reset/{%s}", InputFieldLabelUriVariableId), request.PortalEventHandler.ResetUpload).Methods("DELETE", "OPTIONS")
+    apiRouter.HandleFunc(fmt.Sprintf("/validate/{%s}", StepLabelUriVariableId), request.PortalEventHandler.ValidateStep).Methods("POST")
+    apiRouter.HandleFunc("/draft", request.PortalEventHandler.SaveDraft).Methods("POST")
+    apiRouter.HandleFunc("/events", request.PortalEventHandler.StreamEvents).Methods("GET")
 
 }
@@ -0,0 +1,27 @@
+package portal
+
+import (
+	"net/http"
+
+	"github.com/boasihq/interactive-inputs/internal/submission"
+)
+
+// completionAttempt returns the attempt the request makes to complete the portal in the
+// given way, identified by the idempotency key the portal generates when it is loaded
+func (h *Handler) completionAttempt(r *http.Request, kind string) submission.Attempt {
+	return submission.Attempt{
+		Kind:           kind,
+		IdempotencyKey: r.Header.Get(IdempotencyKeyHeader),
+	}
+}
+
+// writeAlreadySubmittedResponse writes the response for a request to complete the portal
+// after it has already been completed by another request
+func (h *Handler) writeAlreadySubmittedResponse(w http.ResponseWriter, jobUrl string) {
+	winner, _ := h.submission.Winner()
+
+	h.writeResponseTemplateWithStatus(w, http.StatusConflict, "already-submitted.tmpl.html", map[string]interface{}{
+		"JobUrl": jobUrl,
+		"Kind":   winner.Kind,
+	})
+}
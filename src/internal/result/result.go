@@ -0,0 +1,128 @@
+package result
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/boasihq/interactive-inputs/internal/fields"
+)
+
+// Result is how the portal was completed, kept so it can be shown once the portal no
+// longer accepts responses
+type Result struct {
+
+	// Outcome is how the portal was completed, i.e. submitted
+	Outcome string
+
+	// By is who completed the portal, empty when they couldn't be identified
+	By string
+
+	// At is when the portal was completed
+	At time.Time
+
+	// Reason is the reason given with a decision, empty when none was given
+	Reason string
+
+	// Values are the values set as outputs, with the values of secret fields masked
+	Values []fields.ReviewItem
+}
+
+// Store holds the result of the portal once it has been completed. It is safe for
+// concurrent use.
+type Store struct {
+	mu sync.RWMutex
+
+	// result is the result of the portal, nil until it has been completed
+	result *Result
+}
+
+// NewStore returns a store without a result
+func NewStore() *Store {
+	return &Store{}
+}
+
+// Record keeps the result, setting when the portal was completed if it isn't set. Only
+// the first result is kept, so it returns the result kept and whether it was this one.
+func (s *Store) Record(result Result) (Result, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.result != nil {
+		return *s.result, false
+	}
+
+	if result.At.IsZero() {
+		result.At = time.Now().UTC()
+	}
+	s.result = &result
+
+	return result, true
+}
+
+// Result returns the result of the portal, and false when it hasn't been completed
+func (s *Store) Result() (Result, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.result == nil {
+		return Result{}, false
+	}
+
+	return *s.result, true
+}
+
+// Markdown returns the result as markdown, under the title, as it is written to the
+// job summary
+func (r Result) Markdown(title string) string {
+	var summary strings.Builder
+
+	heading := "Interactive Inputs"
+	if title != "" {
+		heading = title
+	}
+
+	fmt.Fprintf(&summary, "### %s\n\n", heading)
+
+	completedBy := ""
+	if r.By != "" {
+		completedBy = fmt.Sprintf(" by **%s**", r.By)
+	}
+	fmt.Fprintf(&summary, "**%s**%s at %s\n\n", r.OutcomeTitle(), completedBy, r.At.UTC().Format("2006-01-02 15:04 UTC"))
+
+	if r.Reason != "" {
+		fmt.Fprintf(&summary, "> %s\n\n", markdownCell(r.Reason))
+	}
+
+	if len(r.Values) == 0 {
+		return summary.String()
+	}
+
+	summary.WriteString("| Field | Value |\n| --- | --- |\n")
+	for _, value := range r.Values {
+		fmt.Fprintf(&summary, "| %s | %s |\n", markdownCell(value.Display), markdownCell(value.Value))
+	}
+
+	return summary.String()
+}
+
+// OutcomeTitle returns the outcome starting with a capital letter, i.e. Submitted
+func (r Result) OutcomeTitle() string {
+	if r.Outcome == "" {
+		return ""
+	}
+
+	return strings.ToUpper(r.Outcome[:1]) + r.Outcome[1:]
+}
+
+// markdownCell returns the text escaped so it is shown as it was entered, on a single line
+// of a markdown table
+func markdownCell(text string) string {
+	text = html.EscapeString(text)
+	text = strings.ReplaceAll(text, "|", "\\|")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	return strings.ReplaceAll(text, "\n", "<br>")
+}
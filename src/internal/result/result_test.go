@@ -0,0 +1,73 @@
+package result_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/boasihq/interactive-inputs/internal/fields"
+	"github.com/boasihq/interactive-inputs/internal/result"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore_Record(t *testing.T) {
+	store := result.NewStore()
+
+	_, ok := store.Result()
+	assert.False(t, ok)
+
+	recorded, ok := store.Record(result.Result{Outcome: "submitted", By: "alice"})
+	assert.True(t, ok)
+	assert.False(t, recorded.At.IsZero())
+
+	// only the first result is kept
+	kept, ok := store.Record(result.Result{Outcome: "cancelled", By: "bob"})
+	assert.False(t, ok)
+	assert.Equal(t, recorded, kept)
+
+	stored, ok := store.Result()
+	assert.True(t, ok)
+	assert.Equal(t, recorded, stored)
+}
+
+func TestResult_Markdown(t *testing.T) {
+	completedAt := time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		title            string
+		result           result.Result
+		expectedMarkdown string
+	}{
+		{
+			name:  "submitted with values",
+			title: "Deploy",
+			result: result.Result{
+				Outcome: "submitted",
+				By:      "alice",
+				At:      completedAt,
+				Values: []fields.ReviewItem{
+					{Label: "environment", Display: "Environment", Value: "<b>production</b>"},
+					{Label: "token", Display: "Token", Value: fields.MaskedValue, Secret: true},
+					{Label: "notes", Display: "Notes | extra", Value: "line one\nline | two"},
+				},
+			},
+			expectedMarkdown: "### Deploy\n\n**Submitted** by **alice** at 2024-05-01 09:30 UTC\n\n" +
+				"| Field | Value |\n| --- | --- |\n" +
+				"| Environment | &lt;b&gt;production&lt;/b&gt; |\n" +
+				"| Token | " + fields.MaskedValue + " |\n" +
+				"| Notes \\| extra | line one<br>line \\| two |\n",
+		},
+		{
+			name:   "rejected with a reason by someone unidentified",
+			result: result.Result{Outcome: "rejected", At: completedAt, Reason: "Not during\nthe freeze"},
+			expectedMarkdown: "### Interactive Inputs\n\n**Rejected** at 2024-05-01 09:30 UTC\n\n" +
+				"> Not during<br>the freeze\n\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedMarkdown, test.result.Markdown(test.title))
+		})
+	}
+}
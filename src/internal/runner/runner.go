@@ -9,10 +9,16 @@ import (
     "strings"
     "time"
 
+	"github.com/boasihq/interactive-inputs/internal/approval"
 	"github.com/boasihq/interactive-inputs/internal/config"
+	"github.com/boasihq/interactive-inputs/internal/draft"
 	"github.com/boasihq/interactive-inputs/internal/errors"
+	"github.com/boasihq/interactive-inputs/internal/fields"
+	"github.com/boasihq/interactive-inputs/internal/live"
+	"github.com/boasihq/interactive-inputs/internal/membership"
 	"github.com/boasihq/interactive-inputs/internal/notifier"
 	"github.com/boasihq/interactive-inputs/internal/portal"
+	"github.com/boasihq/interactive-inputs/internal/result"
 	webui "github.com/boasihq/interactive-inputs/internal/web"
 	"github.com/gorilla/mux"
 	"go.uber.org/zap"
@@ -121,14 +127,108 @@ func InvokeAction(ctx context.Context, ctxCancel context.CancelFunc, cfg *config
 		}
 	}
 
+	// the approvals collected before the portal completes in decision mode
+	quorum := approval.NewQuorum(&approval.NewQuorumRequest{
+		RequiredApprovals: cfg.RequiredApprovals,
+		ApproverTeams:     cfg.ApproverTeams,
+		RequireEachTeam:   cfg.RequireApprovalFromEachTeam,
+	})
+
+	// team membership is looked up with the GitHub API of the server the workflow runs on
+	var githubApiUrl string
+	if actionContext, err := cfg.Action.Context(); err == nil {
+		githubApiUrl = actionContext.APIURL
+	}
+
+	membershipChecker := membership.NewGitHubChecker(&membership.NewGitHubCheckerRequest{
+		Token:  cfg.GithubToken,
+		ApiUrl: githubApiUrl,
+	})
+
+	// the decision settings are only passed in decision mode, so decisions are refused otherwise
+	var decisionSettings *portal.DecisionSettings
+	if cfg.Mode == config.ModeDecision {
+		decisionSettings = &portal.DecisionSettings{
+			ReasonRequiredToApprove: cfg.DecisionReasonRequiredToApprove(),
+			ReasonRequiredToReject:  cfg.DecisionReasonRequiredToReject(),
+			FailOnReject:            cfg.DecisionOnReject == config.DecisionOnRejectFail,
+			Quorum:                  quorum,
+		}
+	}
+
+	// the notifications show the title as it is displayed in the portal
+	notifierTitle := cfg.Title
+	if actionContext, err := cfg.Action.Context(); err == nil {
+		if expandedTitle, err := fields.ExpandTemplate(cfg.Title, fields.TemplateData{GitHub: actionContext, Env: fields.Environment()}); err == nil {
+			notifierTitle = expandedTitle
+		}
+	}
+
+	// the gate settings are only passed in gate mode, so acknowledgements are refused otherwise
+	var gateSettings *portal.GateSettings
+	if cfg.Mode == config.ModeGate {
+		gateSettings = &portal.GateSettings{
+			Checklist:     cfg.GateChecklist,
+			Notifiers:     []notifier.Notifier{slackNotifier, discordNotifier},
+			NotifierTitle: notifierTitle,
+		}
+	}
+
+	// the values entered into the portal are saved as drafts, so they aren't lost on reload
+	drafts := draft.NewStore(&draft.NewStoreRequest{Shared: cfg.SharedDraft})
+
+	// the viewers of the portal are told who else is viewing it, and how it was completed
+	var expiresAt time.Time
+	if deadline, ok := ctx.Deadline(); ok {
+		expiresAt = deadline
+	}
+	liveHub := live.NewHub(&live.NewHubRequest{ExpiresAt: expiresAt})
+
+	// the portal is reachable at its runner endpoint key, on localhost when running locally
+	localPort := ":8080"
+	portalUrl := fmt.Sprintf("%s/%s/", strings.TrimRight(cfg.SelfHostedPublicURL, "/"), strings.Trim(cfg.RunnerEndpointKey, "/ "))
+	if isRunningLocal {
+		portalUrl = fmt.Sprintf("http://localhost%s/%s/", localPort, strings.Trim(cfg.RunnerEndpointKey, "/ "))
+	}
+
+	// the result of the portal is kept, so it can be shown once the portal has been completed
+	results := result.NewStore()
+	resultSettings := &portal.ResultSettings{
+		GracePeriod: time.Duration(cfg.ResultGracePeriod) * time.Second,
+		JobSummary:  cfg.JobSummary,
+		PortalUrl:   portalUrl,
+		Notifiers:   []notifier.Notifier{slackNotifier, discordNotifier},
+		Title:       notifierTitle,
+		Results:     results,
+	}
+
 	/// Handlers
 	uiHandler := webui.NewWebAppHandler(&webui.NewWebAppHandlerRequest{
 		EmbeddedContent:               embeddedContent,
 		EmbeddedContentFilePathPrefix: embeddedContentFilePathPrefix,
 		Config:                        cfg,
+		Quorum:                        quorum,
+		MembershipChecker:             membershipChecker,
+		Drafts:                        drafts,
+		Results:                       results,
 	})
 
-	portalEventHandler := portal.NewHandler(cfg.Action, isRunningLocal, embeddedContent, embeddedContentFilePathPrefix, cfg.GithubToken, inputFieldLabelToCacheDirMapping)
+	portalEventHandler := portal.NewHandler(&portal.NewHandlerRequest{
+		ActionPkg:                        cfg.Action,
+		IsRunningLocal:                   isRunningLocal,
+		EmbeddedContent:                  embeddedContent,
+		EmbeddedContentFilePathPrefix:    embeddedContentFilePathPrefix,
+		GithubToken:                      cfg.GithubToken,
+		InputFieldLabelToCacheDirMapping: inputFieldLabelToCacheDirMapping,
+		Fields:                           cfg.Fields,
+		Decision:                         decisionSettings,
+		Gate:                             gateSettings,
+		IdentityHeader:                   cfg.IdentityHeader,
+		MembershipChecker:                membershipChecker,
+		Drafts:                           drafts,
+		Live:                             liveHub,
+		Result:                           resultSettings,
+	})
 
 	/// Routes
 	r := mux.NewRouter()
@@ -149,18 +249,23 @@ func InvokeAction(ctx context.Context, ctxCancel context.CancelFunc, cfg *config
 	notifierSlackEnterInputMessageTmpl := "<%s|*Enter required input*>"
 	notifierDiscordEnterInputMessageTmpl := "[**Enter required input**](%s)"
 	universalNotifierFailedToSelfHost := "A failure has occurred while starting/running your self-hosted portal: %v"
+	notifierHeading := notifier.HeadingInputRequired
+
+	// a gate is only waiting to be acknowledged
+	if cfg.Mode == config.ModeGate {
+		notifierSlackEnterInputMessageTmpl = "<%s|*Acknowledge the gate*>"
+		notifierDiscordEnterInputMessageTmpl = "[**Acknowledge the gate**](%s)"
+		notifierHeading = notifier.HeadingAcknowledgementRequired
+	}
 
     if isRunningLocal {
-        localPort := ":8080"
         server := &http.Server{Addr: localPort, Handler: r}
-        completeLocalUrl := fmt.Sprintf("http://localhost%s", localPort)
-        // add runner endpoint key to base url
-        completeLocalUrl = fmt.Sprintf("%s/%s/", strings.TrimRight(completeLocalUrl, "/"), strings.Trim(cfg.RunnerEndpointKey, "/ "))
+        completeLocalUrl := portalUrl
         serverInitMessage := fmt.Sprintf(serverInitMessageTmpl, completeLocalUrl)
 
 		cfg.Action.Noticef(serverInitMessage)
 		if slackNotifier.Enabled() {
-			_, err := slackNotifier.Notify(cfg.Title, fmt.Sprintf(notifierSlackEnterInputMessageTmpl, completeLocalUrl))
+			_, err := slackNotifier.Notify(notifierHeading, notifierTitle, fmt.Sprintf(notifierSlackEnterInputMessageTmpl, completeLocalUrl))
 			if err != nil {
 				cfg.Action.Errorf("Slack Notifier Notification Failed: %v", err)
 				return err
@@ -168,7 +273,7 @@ func InvokeAction(ctx context.Context, ctxCancel context.CancelFunc, cfg *config
 		}
 
 		if discordNotifier.Enabled() {
-			_, err := discordNotifier.Notify(cfg.Title, fmt.Sprintf(notifierDiscordEnterInputMessageTmpl, completeLocalUrl))
+			_, err := discordNotifier.Notify(notifierHeading, notifierTitle, fmt.Sprintf(notifierDiscordEnterInputMessageTmpl, completeLocalUrl))
 			if err != nil {
 				cfg.Action.Errorf("Discord Notifier Notification Failed: %v", err)
 				return err
@@ -182,14 +287,14 @@ func InvokeAction(ctx context.Context, ctxCancel context.CancelFunc, cfg *config
 
 				cfg.Action.Errorf(serverErrorMessage)
 				if slackNotifier.Enabled() {
-					_, err := slackNotifier.Notify(cfg.Title, serverErrorMessage)
+					_, err := slackNotifier.Notify(notifierHeading, notifierTitle, serverErrorMessage)
 					if err != nil {
 						cfg.Action.Errorf("Slack Notifier Notification Failed: %v", err)
 					}
 				}
 
 				if discordNotifier.Enabled() {
-					_, err := discordNotifier.Notify(cfg.Title, serverErrorMessage)
+					_, err := discordNotifier.Notify(notifierHeading, notifierTitle, serverErrorMessage)
 					if err != nil {
 						cfg.Action.Errorf("Discord Notifier Notification Failed: %v", err)
 					}
@@ -202,12 +307,12 @@ func InvokeAction(ctx context.Context, ctxCancel context.CancelFunc, cfg *config
 
     } else {
         server := &http.Server{Addr: cfg.SelfHostedListenAddress, Handler: r}
-        publicURL := fmt.Sprintf("%s/%s/", strings.TrimRight(cfg.SelfHostedPublicURL, "/"), strings.Trim(cfg.RunnerEndpointKey, "/ "))
+        publicURL := portalUrl
         serverInitMessage := fmt.Sprintf(serverInitMessageTmpl, publicURL)
 
 		cfg.Action.Noticef(serverInitMessage)
 		if slackNotifier.Enabled() {
-			_, err := slackNotifier.Notify(cfg.Title, fmt.Sprintf(notifierSlackEnterInputMessageTmpl, publicURL))
+			_, err := slackNotifier.Notify(notifierHeading, notifierTitle, fmt.Sprintf(notifierSlackEnterInputMessageTmpl, publicURL))
 			if err != nil {
 				cfg.Action.Errorf("Slack Notifier Notification Failed: %v", err)
 				return err
@@ -215,7 +320,7 @@ func InvokeAction(ctx context.Context, ctxCancel context.CancelFunc, cfg *config
 		}
 
 		if discordNotifier.Enabled() {
-			_, err := discordNotifier.Notify(cfg.Title, fmt.Sprintf(notifierDiscordEnterInputMessageTmpl, publicURL))
+			_, err := discordNotifier.Notify(notifierHeading, notifierTitle, fmt.Sprintf(notifierDiscordEnterInputMessageTmpl, publicURL))
 			if err != nil {
 				cfg.Action.Errorf("Discord Notifier Notification Failed: %v", err)
 				return err
@@ -228,14 +333,14 @@ func InvokeAction(ctx context.Context, ctxCancel context.CancelFunc, cfg *config
 
 				cfg.Action.Errorf(serverErrorMessage)
 				if slackNotifier.Enabled() {
-					_, err := slackNotifier.Notify(cfg.Title, serverErrorMessage)
+					_, err := slackNotifier.Notify(notifierHeading, notifierTitle, serverErrorMessage)
 					if err != nil {
 						cfg.Action.Errorf("Slack Notifier Notification Failed: %v", err)
 					}
 				}
 
 				if discordNotifier.Enabled() {
-					_, err := discordNotifier.Notify(cfg.Title, serverErrorMessage)
+					_, err := discordNotifier.Notify(notifierHeading, notifierTitle, serverErrorMessage)
 					if err != nil {
 						cfg.Action.Errorf("Discord Notifier Notification Failed: %v", err)
 					}
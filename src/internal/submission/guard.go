@@ -0,0 +1,130 @@
+package submission
+
+import (
+	"sync"
+)
+
+// State is where the guard is in its one-shot lifecycle
+type State int
+
+const (
+	// StateOpen is the state of a portal that can still be completed
+	StateOpen State = iota
+
+	// StateCompleting is the state while a completion is being made, during which other
+	// completions wait to find out whether it succeeded
+	StateCompleting
+
+	// StateCompleted is the state once a completion has succeeded, after which the portal
+	// can't be completed again
+	StateCompleted
+)
+
+// Result is what became of a completion
+type Result int
+
+const (
+	// ResultCompleted is the result of the completion that won, and completed the portal
+	ResultCompleted Result = iota
+
+	// ResultDuplicate is the result of a repeat of the completion that won, made with the
+	// same idempotency key, i.e. when the submit button is double-clicked
+	ResultDuplicate
+
+	// ResultAlreadyCompleted is the result of a completion made after another has won
+	ResultAlreadyCompleted
+)
+
+// Attempt is an attempt to complete the portal
+type Attempt struct {
+
+	// Kind is how the portal is being completed, i.e. submitted or cancelled
+	Kind string
+
+	// IdempotencyKey is generated by the client so repeats of the same attempt can be
+	// recognised. Attempts without a key are never treated as repeats
+	IdempotencyKey string
+}
+
+// Guard makes sure the portal is only completed once, by the first attempt that succeeds.
+// Attempts are made one at a time, so attempts made while another is being completed wait
+// for it, winning if it fails. It is safe for concurrent use.
+type Guard struct {
+
+	// mu is held for the whole of an attempt, so attempts are made one at a time
+	mu sync.Mutex
+
+	// stateMu guards state, so it can be read during an attempt
+	stateMu sync.Mutex
+
+	// state is where the guard is in its lifecycle
+	state State
+
+	// winner is the attempt that completed the portal, empty until it has been
+	winner Attempt
+}
+
+// NewGuard returns a guard for a portal that hasn't been completed
+func NewGuard() *Guard {
+	return &Guard{state: StateOpen}
+}
+
+// Complete runs complete for the attempt unless the portal has already been completed, in
+// which case the attempt is recognised as a duplicate of the winner or refused. The portal
+// is completed when complete succeeds, and stays open for the next attempt when it fails,
+// with its error returned.
+func (g *Guard) Complete(attempt Attempt, complete func() error) (Result, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.IsDuplicate(attempt) {
+		return ResultDuplicate, nil
+	}
+
+	if _, ok := g.Winner(); ok {
+		return ResultAlreadyCompleted, nil
+	}
+
+	g.setState(StateCompleting, Attempt{})
+
+	if err := complete(); err != nil {
+		g.setState(StateOpen, Attempt{})
+		return ResultCompleted, err
+	}
+
+	g.setState(StateCompleted, attempt)
+
+	return ResultCompleted, nil
+}
+
+// IsDuplicate returns whether the attempt is a repeat of the one that completed the portal,
+// so it can be answered in the same way before anything else is done for it
+func (g *Guard) IsDuplicate(attempt Attempt) bool {
+	winner, ok := g.Winner()
+	return ok && attempt.IdempotencyKey != "" && attempt == winner
+}
+
+// State returns where the guard is in its lifecycle
+func (g *Guard) State() State {
+	g.stateMu.Lock()
+	defer g.stateMu.Unlock()
+
+	return g.state
+}
+
+// Winner returns the attempt that completed the portal, and false until it has been
+func (g *Guard) Winner() (Attempt, bool) {
+	g.stateMu.Lock()
+	defer g.stateMu.Unlock()
+
+	return g.winner, g.state == StateCompleted
+}
+
+// setState moves the guard to the state, recording the winner once it is completed
+func (g *Guard) setState(state State, winner Attempt) {
+	g.stateMu.Lock()
+	defer g.stateMu.Unlock()
+
+	g.state = state
+	g.winner = winner
+}
@@ -0,0 +1,171 @@
+package submission_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/boasihq/interactive-inputs/internal/submission"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGuard_Complete(t *testing.T) {
+	submitted := submission.Attempt{Kind: "submitted", IdempotencyKey: "key-1"}
+
+	tests := []struct {
+		name           string
+		attempt        submission.Attempt
+		expectedResult submission.Result
+	}{
+		{
+			name:           "a repeat of the winning attempt is a duplicate",
+			attempt:        submitted,
+			expectedResult: submission.ResultDuplicate,
+		},
+		{
+			name:           "an attempt with another idempotency key is refused",
+			attempt:        submission.Attempt{Kind: "submitted", IdempotencyKey: "key-2"},
+			expectedResult: submission.ResultAlreadyCompleted,
+		},
+		{
+			name:           "an attempt to complete the portal another way is refused",
+			attempt:        submission.Attempt{Kind: "cancelled", IdempotencyKey: "key-1"},
+			expectedResult: submission.ResultAlreadyCompleted,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			guard := submission.NewGuard()
+			assert.Equal(t, submission.StateOpen, guard.State())
+
+			result, err := guard.Complete(submitted, func() error {
+				assert.Equal(t, submission.StateCompleting, guard.State())
+				return nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, submission.ResultCompleted, result)
+			assert.Equal(t, submission.StateCompleted, guard.State())
+
+			var called bool
+			result, err = guard.Complete(test.attempt, func() error {
+				called = true
+				return nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedResult, result)
+			assert.False(t, called)
+
+			winner, ok := guard.Winner()
+			assert.True(t, ok)
+			assert.Equal(t, submitted, winner)
+		})
+	}
+}
+
+func TestGuard_CancelAfterDecided(t *testing.T) {
+	for _, kind := range []string{"approved", "rejected", "acknowledged"} {
+		t.Run(kind, func(t *testing.T) {
+			guard := submission.NewGuard()
+			decided := submission.Attempt{Kind: kind, IdempotencyKey: "key-1"}
+
+			result, err := guard.Complete(decided, func() error { return nil })
+			assert.NoError(t, err)
+			assert.Equal(t, submission.ResultCompleted, result)
+
+			// the cancellation is refused, even when made from the same page
+			var cancelled bool
+			result, err = guard.Complete(submission.Attempt{Kind: "cancelled", IdempotencyKey: "key-1"}, func() error {
+				cancelled = true
+				return nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, submission.ResultAlreadyCompleted, result)
+			assert.False(t, cancelled)
+
+			winner, ok := guard.Winner()
+			assert.True(t, ok)
+			assert.Equal(t, decided, winner)
+		})
+	}
+}
+
+func TestGuard_IsDuplicate(t *testing.T) {
+	guard := submission.NewGuard()
+	approved := submission.Attempt{Kind: "approved", IdempotencyKey: "key-1"}
+
+	// nothing is a duplicate until the portal has been completed
+	assert.False(t, guard.IsDuplicate(approved))
+
+	_, err := guard.Complete(approved, func() error { return nil })
+	assert.NoError(t, err)
+
+	assert.True(t, guard.IsDuplicate(approved))
+	assert.False(t, guard.IsDuplicate(submission.Attempt{Kind: "approved", IdempotencyKey: "key-2"}))
+	assert.False(t, guard.IsDuplicate(submission.Attempt{Kind: "rejected", IdempotencyKey: "key-1"}))
+	assert.False(t, guard.IsDuplicate(submission.Attempt{Kind: "approved"}))
+}
+
+func TestGuard_CompleteWithoutIdempotencyKey(t *testing.T) {
+	guard := submission.NewGuard()
+	attempt := submission.Attempt{Kind: "submitted"}
+
+	_, err := guard.Complete(attempt, func() error { return nil })
+	assert.NoError(t, err)
+
+	// attempts without a key can't be recognised as repeats
+	result, err := guard.Complete(attempt, func() error { return nil })
+	assert.NoError(t, err)
+	assert.Equal(t, submission.ResultAlreadyCompleted, result)
+}
+
+func TestGuard_CompleteFailed(t *testing.T) {
+	guard := submission.NewGuard()
+	failure := errors.New("unable to set outputs")
+
+	_, err := guard.Complete(submission.Attempt{Kind: "submitted", IdempotencyKey: "key-1"}, func() error { return failure })
+	assert.Equal(t, failure, err)
+	assert.Equal(t, submission.StateOpen, guard.State())
+
+	_, ok := guard.Winner()
+	assert.False(t, ok)
+
+	// the portal stays open for the next attempt
+	result, err := guard.Complete(submission.Attempt{Kind: "submitted", IdempotencyKey: "key-2"}, func() error { return nil })
+	assert.NoError(t, err)
+	assert.Equal(t, submission.ResultCompleted, result)
+}
+
+func TestGuard_CompleteConcurrently(t *testing.T) {
+	guard := submission.NewGuard()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var completions int
+	var results map[submission.Result]int = make(map[submission.Result]int)
+
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			result, err := guard.Complete(submission.Attempt{Kind: "submitted"}, func() error {
+				mu.Lock()
+				defer mu.Unlock()
+
+				completions++
+				return nil
+			})
+			assert.NoError(t, err)
+
+			mu.Lock()
+			defer mu.Unlock()
+			results[result]++
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, completions)
+	assert.Equal(t, 1, results[submission.ResultCompleted])
+	assert.Equal(t, 19, results[submission.ResultAlreadyCompleted])
+}
@@ -0,0 +1,37 @@
+package toolbox
+
+import "gopkg.in/yaml.v3"
+
+// YamlMappingValue returns the value of the key within the mapping node, or nil if the
+// node isn't a mapping or doesn't have the key
+func YamlMappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+// YamlSetMappingValue sets the value of the key within the mapping node, adding the key
+// if the node doesn't have it
+func YamlSetMappingValue(node *yaml.Node, key *yaml.Node, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key.Value {
+			node.Content[i+1] = value
+			return
+		}
+	}
+
+	node.Content = append(node.Content, key, value)
+}
+
+// YamlStringNode returns a scalar node holding the string value
+func YamlStringNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
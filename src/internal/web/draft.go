@@ -0,0 +1,79 @@
+package webui
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/boasihq/interactive-inputs/internal/draft"
+)
+
+// DraftContent holds the draft restored into the portal when it is loaded, which is saved
+// again as values are entered
+type DraftContent struct {
+
+	// Values are the saved values, keyed by input name
+	Values map[string][]string `json:"values"`
+
+	// SavedAt is when the draft was last saved, nil if it hasn't been saved yet
+	SavedAt *time.Time `json:"saved_at"`
+
+	// Shared is true when the draft is shared by everyone filling in the portal
+	Shared bool `json:"shared"`
+}
+
+// JSON returns the draft encoded as JSON, so it can be passed to the form component
+func (d DraftContent) JSON() (string, error) {
+	encoded, err := json.Marshal(d)
+	if err != nil {
+		return "", err
+	}
+
+	return string(encoded), nil
+}
+
+// GroupItems returns the ids of the items of the group field that have values saved in
+// the draft, encoded as JSON, so the group is restored with the same items
+func (d DraftContent) GroupItems(groupLabel string) (string, error) {
+	var itemIds []int = []int{}
+	var seen map[int]bool = make(map[int]bool)
+
+	// the inputs of a group's items are named group.id.sub-field
+	for inputName := range d.Values {
+		if !strings.HasPrefix(inputName, groupLabel+".") {
+			continue
+		}
+
+		id, _, ok := strings.Cut(strings.TrimPrefix(inputName, groupLabel+"."), ".")
+		itemId, err := strconv.Atoi(id)
+		if !ok || err != nil || itemId < 0 || seen[itemId] {
+			continue
+		}
+
+		seen[itemId] = true
+		itemIds = append(itemIds, itemId)
+	}
+
+	sort.Ints(itemIds)
+
+	encoded, err := json.Marshal(itemIds)
+	if err != nil {
+		return "", err
+	}
+
+	return string(encoded), nil
+}
+
+// renderDraft returns the draft of the session to restore into the portal
+func renderDraft(drafts *draft.Store, session string) *DraftContent {
+	content := &DraftContent{Values: map[string][]string{}, Shared: drafts.Shared()}
+
+	if saved, ok := drafts.Load(session); ok {
+		content.Values = saved.Values
+		content.SavedAt = &saved.SavedAt
+	}
+
+	return content
+}
@@ -0,0 +1,40 @@
+package webui
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+
+	"github.com/boasihq/interactive-inputs/internal/fields"
+)
+
+// FieldView is the data the template partial of a field is rendered with. It holds the
+// field along with the data of the portal, so the partial can look up the content
+// rendered for the field, i.e. its description.
+type FieldView struct {
+	*CreateInteractiveInputsPortalRequest
+
+	// Field is the field being rendered
+	Field fields.Field
+}
+
+// renderField renders the field with the template partial of its type
+func renderField(templates *template.Template, response *CreateInteractiveInputsPortalRequest, field fields.Field) (template.HTML, error) {
+	partial := field.FieldType().Template
+	if templates.Lookup(partial) == nil {
+		return "", fmt.Errorf("no template partial '%s' found for field '%s'", partial, field.Label)
+	}
+
+	var rendered bytes.Buffer
+	if err := templates.ExecuteTemplate(&rendered, partial, FieldView{response, field}); err != nil {
+		return "", err
+	}
+
+	// read-only fields are disabled as a whole, so whatever the partial renders for them
+	// can't be changed and isn't submitted
+	if field.Properties.ReadOnly {
+		return template.HTML(`<fieldset disabled style="display: contents">` + rendered.String() + `</fieldset>`), nil
+	}
+
+	return template.HTML(rendered.String()), nil
+}
@@ -8,7 +8,12 @@ import (
     "os"
     "strings"
 
+    "github.com/boasihq/interactive-inputs/internal/approval"
     "github.com/boasihq/interactive-inputs/internal/config"
+    "github.com/boasihq/interactive-inputs/internal/draft"
+    "github.com/boasihq/interactive-inputs/internal/fields"
+    "github.com/boasihq/interactive-inputs/internal/membership"
+    "github.com/boasihq/interactive-inputs/internal/result"
     "github.com/boasihq/interactive-inputs/internal/toolbox"
     githubactions "github.com/sethvargo/go-githubactions"
     "go.uber.org/zap"
@@ -21,6 +26,15 @@ type NewWebAppHandlerRequest struct {
 	EmbeddedContentFilePathPrefix string
 	// Config is the configuration of the action
 	Config *config.Config
+	// Quorum collects the approvals given in decision mode
+	Quorum *approval.Quorum
+	// MembershipChecker checks the GitHub teams of who is using the portal
+	MembershipChecker membership.Checker
+	// Drafts holds the values entered into the portal that haven't been submitted yet
+	Drafts *draft.Store
+	// Results holds the result of the portal, shown in place of the portal once it has
+	// been completed
+	Results *result.Store
 }
 
 // NewWebAppHandler creates a new instance of an ui handler
@@ -30,6 +44,10 @@ func NewWebAppHandler(r *NewWebAppHandlerRequest) *Handler {
 		embeddedContentFilePathPrefix: r.EmbeddedContentFilePathPrefix,
 		action:                        r.Config.Action,
 		config:                        r.Config,
+		quorum:                        r.Quorum,
+		membershipChecker:             r.MembershipChecker,
+		drafts:                        r.Drafts,
+		results:                       r.Results,
 	}
 }
 
@@ -39,6 +57,10 @@ type Handler struct {
 	embeddedContentFilePathPrefix string
 	action                        *githubactions.Action
 	config                        *config.Config
+	quorum                        *approval.Quorum
+	membershipChecker             membership.Checker
+	drafts                        *draft.Store
+	results                       *result.Store
 }
 
 func (h *Handler) Home(w http.ResponseWriter, r *http.Request) {
@@ -56,11 +78,61 @@ func (h *Handler) Home(w http.ResponseWriter, r *http.Request) {
 	repoOwner, _ := actionContext.Repo()
     response = &CreateInteractiveInputsPortalRequest{
         RepoOwner: repoOwner,
-        Title:     h.config.Title,
         Fields:    h.config.Fields,
         Timeout:   toolbox.SecondsToMinutes(h.config.Timeout),
     }
 
+    // Expand the templated title, intro and field properties. No answers are available
+    // yet, the defaults of later steps are refreshed as the user moves through them
+    templateData := fields.TemplateData{GitHub: actionContext, Env: fields.Environment()}
+
+    response.Title, err = fields.ExpandTemplate(h.config.Title, templateData)
+    if err != nil {
+        h.action.Errorf("Unable to expand the title: %v", zap.Error(err))
+        http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+        return
+    }
+
+    // Once the portal has been completed it no longer accepts responses, so its result is
+    // shown instead for as long as it is served
+    if h.results != nil {
+        if completed, ok := h.results.Result(); ok {
+            h.writeResultPage(w, actionContext, response.Title, completed)
+            return
+        }
+    }
+
+    intro, err := fields.ExpandTemplate(h.config.Intro, templateData)
+    if err != nil {
+        h.action.Errorf("Unable to expand the intro: %v", zap.Error(err))
+        http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+        return
+    }
+
+    if h.config.Fields != nil {
+        response.Fields, err = h.config.Fields.WithExpandedProperties(templateData)
+        if err != nil {
+            h.action.Errorf("Unable to expand field properties: %v", zap.Error(err))
+            http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+            return
+        }
+    }
+
+    // Lock the fields, and disable the choices, limited to teams the user isn't a member of.
+    // Submissions are checked regardless, so the portal is still shown when the teams can't
+    // be checked
+    if response.Fields != nil && response.Fields.HasPermissions() {
+        identity := strings.TrimSpace(r.Header.Get(h.config.IdentityHeader))
+        permittedFields, err := response.Fields.WithPermissions(func(teams []string) (bool, error) {
+            return membership.IsMemberOfAny(h.membershipChecker, teams, identity)
+        })
+        if err == nil {
+            response.Fields = permittedFields
+        } else {
+            h.action.Warningf("Unable to check the field permissions of %s: %v", identity, err)
+        }
+    }
+
     // Calculate the base path once for the template
     basePath := strings.Trim(h.config.RunnerEndpointKey, "/ ")
     if basePath == "" {
@@ -68,6 +140,43 @@ func (h *Handler) Home(w http.ResponseWriter, r *http.Request) {
     }
     response.BasePath = "/" + basePath
 
+    // Restore the draft saved by the session, starting a session to save the draft with
+    // when the request doesn't belong to one. The portal is still shown without a draft
+    if h.drafts != nil && response.Fields != nil {
+        if session, err := h.drafts.StartSession(w, r); err == nil {
+            response.Draft = renderDraft(h.drafts, session)
+        } else {
+            h.action.Warningf("Unable to start a session to save drafts with: %v", err)
+        }
+    }
+
+    if h.config.Mode == config.ModeDecision {
+        response.Decision = &DecisionPrompt{
+            ReasonRequiredToApprove: h.config.DecisionReasonRequiredToApprove(),
+            ReasonRequiredToReject:  h.config.DecisionReasonRequiredToReject(),
+        }
+
+        // show who is deciding and the approvals given so far when a quorum is needed
+        if h.config.IdentityHeader != "" {
+            response.Decision.Identity = strings.TrimSpace(r.Header.Get(h.config.IdentityHeader))
+        }
+
+        if h.quorum != nil && (h.config.RequiredApprovals > 1 || len(h.config.ApproverTeams) > 0) {
+            status := h.quorum.Status()
+            response.Decision.Quorum = &status
+        }
+    }
+
+    if h.config.Mode == config.ModeGate {
+        response.Gate = &GatePrompt{Checklist: h.config.GateChecklist}
+
+        if h.config.IdentityHeader != "" {
+            response.Gate.Identity = strings.TrimSpace(r.Header.Get(h.config.IdentityHeader))
+        }
+    }
+
+    response.Review = h.config.Mode == config.ModeForm && h.config.ReviewBeforeSubmit
+
     // Build balloon suggestion data from field properties and environment
     balloonData := make(map[string][]string)
     preOutput := make(map[string]struct{ Title, Value string })
@@ -107,16 +216,65 @@ func (h *Handler) Home(w http.ResponseWriter, r *http.Request) {
     response.BalloonData = balloonData
     response.PreOutput = preOutput
 
+    // Render the intro and the descriptions of the fields, which can contain markdown
+    if intro != "" {
+        if renderedIntro, err := fields.RenderMarkdown(intro); err == nil {
+            response.Intro = renderedIntro
+        } else {
+            h.action.Warningf("Unable to render markdown for the intro: %v", err)
+        }
+    }
+
+    response.Descriptions = make(map[string]template.HTML)
+    if response.Fields != nil {
+        for _, f := range response.Fields.Fields {
+            if f.Properties.Description == "" {
+                continue
+            }
+
+            if description, err := fields.RenderMarkdown(f.Properties.Description); err == nil {
+                response.Descriptions[f.Label] = description
+            } else {
+                h.action.Warningf("Unable to render markdown for the description of field '%s': %v", f.Label, err)
+            }
+        }
+    }
+
+    // Prepare the content displayed by the fields whose types prepare any. Files are read on
+    // each request so the portal reflects the workspace as it is when the page is loaded
+    response.Content = make(map[string]interface{})
+    if response.Fields != nil {
+        for _, f := range response.Fields.Fields {
+            content, ok, err := f.Content()
+            if !ok {
+                continue
+            }
+
+            if err != nil {
+                h.action.Warningf("Unable to prepare the content of field '%s': %v", f.Label, err)
+            }
+            if content != nil {
+                response.Content[f.Label] = content
+            }
+        }
+    }
+
 	// list of template files to parse, must be in order of inheritence
 	templateFilesToParse := []string{
 		fmt.Sprintf("%sweb/ui/html/index.tmpl.html", h.embeddedContentFilePathPrefix),
 		fmt.Sprintf("%sweb/ui/html/partials/shared/head-meta.tmpl.html", h.embeddedContentFilePathPrefix),
 		fmt.Sprintf("%sweb/ui/html/pages/@landing.tmpl.html", h.embeddedContentFilePathPrefix),
 		fmt.Sprintf("%sweb/ui/html/partials/shared/tailwind-dash-script.tmpl.html", h.embeddedContentFilePathPrefix),
+		fmt.Sprintf("%sweb/ui/html/partials/fields/*.tmpl.html", h.embeddedContentFilePathPrefix),
 	}
 
-	// Parse template
-	parsedTemplates, err := template.ParseFS(h.embeddedFileSystem, templateFilesToParse...)
+	// Parse template, each field is rendered by the template partial of its type
+	var parsedTemplates *template.Template
+	parsedTemplates, err = template.New("base").Funcs(template.FuncMap{
+		"renderField": func(field fields.Field) (template.HTML, error) {
+			return renderField(parsedTemplates, response, field)
+		},
+	}).ParseFS(h.embeddedFileSystem, templateFilesToParse...)
 	if err != nil {
 		h.action.Errorf("Unable to parse referenced template: %v", zap.Error(err))
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
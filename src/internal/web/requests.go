@@ -1,6 +1,11 @@
 package webui
 
-import "github.com/boasihq/interactive-inputs/internal/fields"
+import (
+	"html/template"
+
+	"github.com/boasihq/interactive-inputs/internal/approval"
+	"github.com/boasihq/interactive-inputs/internal/fields"
+)
 
 // CreateInteractiveInputsPortalRequest is the request that will
 // be used to create a new interactive inputs portal.
@@ -8,6 +13,9 @@ type CreateInteractiveInputsPortalRequest struct {
 	// Title is the header that will be displayed at the top of the generated form
 	Title string
 
+	// Intro is the rendered markdown displayed at the top of the generated form, below the title
+	Intro template.HTML
+
 	// Fields is the slice of fields that will be displayed in the generated form
 	Fields *fields.Fields
 
@@ -21,6 +29,20 @@ type CreateInteractiveInputsPortalRequest struct {
     // BasePath is the path prefix to reach this runner's portal, e.g. "/run-123"
     BasePath string
 
+    // Decision holds how the portal is approved or rejected, nil unless in decision mode
+    Decision *DecisionPrompt
+
+    // Gate holds how the portal is acknowledged, nil unless in gate mode
+    Gate *GatePrompt
+
+    // Review is true when the entered values are reviewed, as they will be set as outputs,
+    // before they are submitted
+    Review bool
+
+    // Draft holds the values restored from the draft saved as the portal was filled in,
+    // nil when the portal has no fields
+    Draft *DraftContent
+
     // BalloonData holds per-field suggestion values for the scroll balloon UI
     // map key: field label; value: list of suggestions
     BalloonData map[string][]string
@@ -28,4 +50,36 @@ type CreateInteractiveInputsPortalRequest struct {
     // PreOutput holds a small read-only output (e.g. previous step result) to
     // display above a field. Keyed by field label.
     PreOutput map[string]struct{ Title, Value string }
+
+    // Descriptions holds the rendered markdown descriptions of fields, keyed by field label
+    Descriptions map[string]template.HTML
+
+    // Content holds what is displayed by the fields whose types prepare it, i.e. the
+    // rendered markdown of markdown fields, keyed by field label
+    Content map[string]interface{}
+}
+
+// DecisionPrompt holds what is needed to approve or reject the portal in decision mode
+type DecisionPrompt struct {
+	// ReasonRequiredToApprove is true when a reason must be given to approve
+	ReasonRequiredToApprove bool
+
+	// ReasonRequiredToReject is true when a reason must be given to reject
+	ReasonRequiredToReject bool
+
+	// Identity is who is deciding, as set by the trusted proxy in front of the portal
+	Identity string
+
+	// Quorum holds the approvals given so far, nil unless more than one approval, or an
+	// approval from the approver teams, is needed
+	Quorum *approval.Status
+}
+
+// GatePrompt holds what is needed to acknowledge the portal in gate mode
+type GatePrompt struct {
+	// Checklist are the items that must all be ticked before the gate is acknowledged
+	Checklist []string
+
+	// Identity is who is acknowledging, as set by the trusted proxy in front of the portal
+	Identity string
 }
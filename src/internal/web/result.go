@@ -0,0 +1,61 @@
+package webui
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+
+	"github.com/boasihq/interactive-inputs/internal/result"
+	githubactions "github.com/sethvargo/go-githubactions"
+	"go.uber.org/zap"
+)
+
+// ResultPage is the read-only page served in place of the portal once it has been completed
+type ResultPage struct {
+	// Title is the header displayed at the top of the page, as it was in the portal
+	Title string
+
+	// RepoOwner is the name that will feature in the page's title
+	RepoOwner string
+
+	// JobUrl is the url of the run the portal belongs to
+	JobUrl string
+
+	// Result is how the portal was completed, along with the values set as outputs
+	Result result.Result
+}
+
+// writeResultPage writes the read-only page showing how the portal was completed
+func (h *Handler) writeResultPage(w http.ResponseWriter, actionContext *githubactions.GitHubContext, title string, completed result.Result) {
+	repoOwner, repoName := actionContext.Repo()
+
+	page := &ResultPage{
+		Title:     title,
+		RepoOwner: repoOwner,
+		JobUrl:    fmt.Sprintf("%s/%s/actions/runs/%d", actionContext.ServerURL, repoOwner+"/"+repoName, actionContext.RunID),
+		Result:    completed,
+	}
+
+	// list of template files to parse, must be in order of inheritence
+	templateFilesToParse := []string{
+		fmt.Sprintf("%sweb/ui/html/index.tmpl.html", h.embeddedContentFilePathPrefix),
+		fmt.Sprintf("%sweb/ui/html/partials/shared/head-meta.tmpl.html", h.embeddedContentFilePathPrefix),
+		fmt.Sprintf("%sweb/ui/html/pages/@result.tmpl.html", h.embeddedContentFilePathPrefix),
+		fmt.Sprintf("%sweb/ui/html/partials/shared/tailwind-dash-script.tmpl.html", h.embeddedContentFilePathPrefix),
+	}
+
+	parsedTemplates, err := template.New("base").ParseFS(h.embeddedFileSystem, templateFilesToParse...)
+	if err != nil {
+		h.action.Errorf("Unable to parse referenced template: %v", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	// Write template to response
+	err = parsedTemplates.ExecuteTemplate(w, "base", page)
+	if err != nil {
+		h.action.Errorf("Unable to execute parsed template: %v", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+}